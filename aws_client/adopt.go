@@ -0,0 +1,78 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+// FindBotByName returns the id of the bot with the given name, or an empty
+// id if there is none. more than one bot with the name is an error
+func (c *AwsClient) FindBotByName(ctx context.Context, name string) (string, error) {
+
+	listBotsOutput, err := c.Client.ListBots(ctx, &lexmodelsv2.ListBotsInput{
+		Filters: []types.BotFilter{
+			{
+				Name:     types.BotFilterNameBotName,
+				Operator: types.BotFilterOperatorEquals,
+				Values:   []string{name},
+			},
+		},
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("error listing bots named %s: %s", name, err)
+	}
+
+	botIds := []string{}
+
+	for _, summary := range listBotsOutput.BotSummaries {
+		if aws.ToString(summary.BotName) == name {
+			botIds = append(botIds, aws.ToString(summary.BotId))
+		}
+	}
+
+	switch len(botIds) {
+	case 0:
+		return "", nil
+	case 1:
+		return botIds[0], nil
+	default:
+		return "", fmt.Errorf("found %d bots named %s: %v", len(botIds), name, botIds)
+	}
+}
+
+// adoptBot continues the create with the bot of the same name, if any.
+// the bot must use the configured role, to not take over an unrelated bot
+func (c *AwsClient) adoptBot(ctx context.Context, bot *LexBot) error {
+
+	botId, err := c.FindBotByName(ctx, bot.Name)
+
+	if err != nil || botId == "" {
+		return err
+	}
+
+	describeBotOutput, err := c.Client.DescribeBot(ctx, &lexmodelsv2.DescribeBotInput{
+		BotId: &botId,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error describing bot %s: %s", botId, err)
+	}
+
+	if aws.ToString(describeBotOutput.RoleArn) != bot.IamRoleArn {
+		return fmt.Errorf("cannot adopt bot %s (%s): its role %s is not the configured role %s",
+			bot.Name, botId, aws.ToString(describeBotOutput.RoleArn), bot.IamRoleArn)
+	}
+
+	log.Printf("[DEBUG] adopting existing bot %s: %s\n", bot.Name, botId)
+
+	bot.Id = botId
+
+	// the adopted bot was tagged by an earlier run, if at all
+	return c.updateTags(ctx, c.BotArn(botId), bot.Tags)
+}
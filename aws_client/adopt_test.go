@@ -0,0 +1,71 @@
+package aws_client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestAdoptBot(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		ListBotsOutput: lexmodelsv2.ListBotsOutput{
+			BotSummaries: []types.BotSummary{
+				{BotId: getAddr("OTHERID"), BotName: getAddr("bot-test-other")},
+				{BotId: getAddr("BOTID"), BotName: getAddr("bot-test")},
+			},
+		},
+		DescribeBotOutput: lexmodelsv2.DescribeBotOutput{
+			BotName: getAddr("bot-test"),
+			RoleArn: getAddr("some-arn"),
+		},
+	})
+
+	botId, err := awsClient.FindBotByName(context.TODO(), "bot-test")
+
+	if err != nil || botId != "BOTID" {
+		t.Errorf("expected to find bot BOTID, got: %s, err: %v", botId, err)
+	}
+
+	botId, err = awsClient.FindBotByName(context.TODO(), "bot-test-missing")
+
+	if err != nil || botId != "" {
+		t.Errorf("expected no bot, got: %s, err: %v", botId, err)
+	}
+
+	bot := LexBot{Name: "bot-test", IamRoleArn: "some-arn"}
+
+	if err = awsClient.adoptBot(context.TODO(), &bot); err != nil || bot.Id != "BOTID" {
+		t.Errorf("expected bot BOTID to be adopted, got: %s, err: %v", bot.Id, err)
+	}
+
+	// a bot using another role is not adopted
+	bot = LexBot{Name: "bot-test", IamRoleArn: "some-other-arn"}
+
+	err = awsClient.adoptBot(context.TODO(), &bot)
+
+	if err == nil || !strings.Contains(err.Error(), "some-other-arn") || bot.Id != "" {
+		t.Errorf("expected the role mismatch to be an error, got: %v", err)
+	}
+}
+
+func TestFindBotByNameAmbiguous(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		ListBotsOutput: lexmodelsv2.ListBotsOutput{
+			BotSummaries: []types.BotSummary{
+				{BotId: getAddr("BOTID1"), BotName: getAddr("bot-test")},
+				{BotId: getAddr("BOTID2"), BotName: getAddr("bot-test")},
+			},
+		},
+	})
+
+	_, err := awsClient.FindBotByName(context.TODO(), "bot-test")
+
+	if err == nil || !strings.Contains(err.Error(), "found 2 bots") {
+		t.Errorf("expected more than one bot to be an error, got: %v", err)
+	}
+}
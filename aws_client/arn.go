@@ -0,0 +1,47 @@
+package aws_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+const lexService = "lex"
+
+// Partition returns the aws partition a region belongs to
+func Partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "us-isob-"):
+		return "aws-iso-b"
+	case strings.HasPrefix(region, "us-iso-"):
+		return "aws-iso"
+	default:
+		return "aws"
+	}
+}
+
+func (c *AwsClient) lexArn(resource string) string {
+	return arn.ARN{
+		Partition: Partition(c.Region),
+		Service:   lexService,
+		Region:    c.Region,
+		AccountID: c.AccountId,
+		Resource:  resource,
+	}.String()
+}
+
+// BotArn returns the arn of a bot. lex has no arns for bot versions and
+// locales, iam authorizes calls on them against the arn of the bot
+func (c *AwsClient) BotArn(botId string) string {
+	return c.lexArn(fmt.Sprintf("bot/%s", botId))
+}
+
+// AliasArn returns the arn of a bot alias
+func (c *AwsClient) AliasArn(botId string, aliasId string) string {
+	return c.lexArn(fmt.Sprintf("bot-alias/%s/%s", botId, aliasId))
+}
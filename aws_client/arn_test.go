@@ -0,0 +1,53 @@
+package aws_client
+
+import "testing"
+
+func TestArns(t *testing.T) {
+
+	tests := []struct {
+		region   string
+		botArn   string
+		aliasArn string
+	}{
+		{"us-west-2",
+			"arn:aws:lex:us-west-2:abcd:bot/BOTID",
+			"arn:aws:lex:us-west-2:abcd:bot-alias/BOTID/ALIASID"},
+		{"cn-north-1",
+			"arn:aws-cn:lex:cn-north-1:abcd:bot/BOTID",
+			"arn:aws-cn:lex:cn-north-1:abcd:bot-alias/BOTID/ALIASID"},
+		{"us-gov-west-1",
+			"arn:aws-us-gov:lex:us-gov-west-1:abcd:bot/BOTID",
+			"arn:aws-us-gov:lex:us-gov-west-1:abcd:bot-alias/BOTID/ALIASID"},
+	}
+
+	for _, test := range tests {
+
+		awsClient, _ := NewTestClient(MockBotClient{})
+		awsClient.Region = test.region
+
+		if arn := awsClient.BotArn("BOTID"); arn != test.botArn {
+			t.Errorf("expected bot arn %s, got %s", test.botArn, arn)
+		}
+		if arn := awsClient.AliasArn("BOTID", "ALIASID"); arn != test.aliasArn {
+			t.Errorf("expected alias arn %s, got %s", test.aliasArn, arn)
+		}
+	}
+}
+
+func TestPartition(t *testing.T) {
+
+	partitions := map[string]string{
+		"us-east-1":      "aws",
+		"eu-west-2":      "aws",
+		"cn-northwest-1": "aws-cn",
+		"us-gov-east-1":  "aws-us-gov",
+		"us-iso-east-1":  "aws-iso",
+		"us-isob-east-1": "aws-iso-b",
+	}
+
+	for region, expected := range partitions {
+		if partition := Partition(region); partition != expected {
+			t.Errorf("expected partition %s for %s, got %s", expected, region, partition)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
@@ -26,19 +27,35 @@ type LexBot struct {
 	IamRoleArn     string
 	SourceCodeHash string
 	Tags           map[string]string
+	// the alias has the same tags as the bot, see GetBot
+	AliasTagsMatch bool
+	Locales        []LexBotLocale
+	// the locales before an update, see deleteRemovedVocabularies
+	PreviousLocales []LexBotLocale
+	// question and answer pairs generated into the archive, see QnaArchive
+	Qna []LexQna
+	// add and remove the permission for the alias to invoke the lambda
+	ManageLambdaPermission bool
+	// create steps completed so far, see CreateBot
+	CompletedSteps []string
+	// continue with an existing bot of the same name instead of failing
+	AdoptExisting bool
 }
 
 // wait up to this many seconds for long-running bot operations to to complete
 const BotWaitTimeoutSec = 60
 const DraftVersion = "DRAFT"
 
+// alias lex creates with every bot
+const OriginalAliasName = "TestBotAlias"
+
 var ttl int32 = 100
 
-func (c *AwsClient) GetBot(botId string, alias string) (LexBot, error) {
+func (c *AwsClient) GetBot(ctx context.Context, botId string, alias string) (LexBot, error) {
 
 	var bot LexBot
 
-	botDescription, err := c.Client.DescribeBot(context.TODO(),
+	botDescription, err := c.Client.DescribeBot(ctx,
 		&lexmodelsv2.DescribeBotInput{
 			BotId: &botId,
 		})
@@ -53,7 +70,17 @@ func (c *AwsClient) GetBot(botId string, alias string) (LexBot, error) {
 	}
 	bot.IamRoleArn = *botDescription.RoleArn
 
-	botAlias, err := c.Client.ListBotAliases(context.TODO(),
+	// without an alias there are no alias tags to differ
+	bot.AliasTagsMatch = true
+
+	// get tags associated with the bot
+	bot.Tags, err = c.listTags(ctx, c.BotArn(botId))
+
+	if err != nil {
+		return LexBot{}, fmt.Errorf("error listing tags of bot %s: %s", botId, err)
+	}
+
+	botAlias, err := c.Client.ListBotAliases(ctx,
 		&lexmodelsv2.ListBotAliasesInput{
 			BotId: &botId,
 		})
@@ -78,22 +105,19 @@ func (c *AwsClient) GetBot(botId string, alias string) (LexBot, error) {
 	if bot.AliasId != "" {
 
 		// get tags associated with the bot alias
-		listTagsForResourceOutput, err := c.Client.ListTagsForResource(context.TODO(),
-			&lexmodelsv2.ListTagsForResourceInput{
-				ResourceARN: getAddr(getAliasArn(botId, bot.AliasId, c.Region, c.AccountId)),
-			})
+		aliasTags, err := c.listTags(ctx, c.AliasArn(botId, bot.AliasId))
 
-		if err == nil && listTagsForResourceOutput != nil && listTagsForResourceOutput.Tags != nil {
-			bot.Tags = make(map[string]string)
-			for key, val := range listTagsForResourceOutput.Tags {
-				bot.Tags[key] = val
-			}
+		if err != nil {
+			return LexBot{}, fmt.Errorf("error listing tags of bot alias %s: %s", bot.AliasId, err)
 		}
 
+		// bot.Tags are those of the bot, the alias is only compared with them
+		bot.AliasTagsMatch = c.tagsMatch(bot.Tags, aliasTags)
+
 		// describe the bot to get its lambda arn
 		var describeBotAliasOutput *lexmodelsv2.DescribeBotAliasOutput
 
-		describeBotAliasOutput, err = c.Client.DescribeBotAlias(context.TODO(),
+		describeBotAliasOutput, err = c.Client.DescribeBotAlias(ctx,
 			&lexmodelsv2.DescribeBotAliasInput{
 				BotAliasId: &bot.AliasId,
 				BotId:      &botId,
@@ -117,7 +141,7 @@ func (c *AwsClient) GetBot(botId string, alias string) (LexBot, error) {
 		// describe the bot version to get its source code hash
 		var describeBotVersionOutput *lexmodelsv2.DescribeBotVersionOutput
 
-		describeBotVersionOutput, err = c.Client.DescribeBotVersion(context.TODO(),
+		describeBotVersionOutput, err = c.Client.DescribeBotVersion(ctx,
 			&lexmodelsv2.DescribeBotVersionInput{
 				BotId:      &botId,
 				BotVersion: &bot.Version,
@@ -136,75 +160,135 @@ func (c *AwsClient) GetBot(botId string, alias string) (LexBot, error) {
 	return bot, err
 }
 
-func (c *AwsClient) CreateBot(bot *LexBot) error {
+// steps of the bot creation, recorded in LexBot.CompletedSteps as they
+// complete so a failed create can be resumed
+const StepCreateBot = "create_bot"
+const StepImport = "import"
+const StepOriginalAlias = "original_alias"
+const StepLocales = "locales"
+const StepBuild = "build"
+const StepVersion = "version"
+const StepAlias = "alias"
+const StepLambdaPermission = "lambda_permission"
+
+type createStep struct {
+	name string
+	run  func(ctx context.Context, bot *LexBot) error
+}
 
-	// create the bot skeleton in aws
-	err := c.createBot(bot)
-	if err != nil {
-		return err
+func (c *AwsClient) createSteps() []createStep {
+	return []createStep{
+		// create the bot skeleton in aws
+		{StepCreateBot, c.createBot},
+		// put the archive containing intents and slots in s3
+		// (in a location determined by the aws lex sdk), import the
+		// bot intents and slots into the bot and set the version of
+		// the imported bot
+		{StepImport, func(ctx context.Context, bot *LexBot) error {
+			uploadId, err := c.uploadArchive(ctx, bot)
+			if err != nil {
+				return err
+			}
+			err = c.importBot(ctx, uploadId, *bot)
+			if err != nil {
+				return err
+			}
+			return c.setImportedVersion(ctx, bot)
+		}},
+		// update the original alias to reference the desired lambda
+		{StepOriginalAlias, c.updateOriginalAlias},
+		// apply locale overrides and custom vocabularies on top of the
+		// imported locales
+		{StepLocales, func(ctx context.Context, bot *LexBot) error {
+			err := c.updateLocales(ctx, bot)
+			if err != nil {
+				return err
+			}
+			return c.importCustomVocabularies(ctx, bot)
+		}},
+		// build the bot
+		{StepBuild, c.buildBot},
+		// create a new version for the imported bot
+		{StepVersion, c.createVersion},
+		// create an alias to the new version whose name matches the
+		// alias defined in the tf bot resource (or, when resuming, point
+		// the alias created before the failure at the new version)
+		{StepAlias, c.createOrUpdateAlias},
+		// allow the new alias to invoke the fulfillment lambda
+		{StepLambdaPermission, func(ctx context.Context, bot *LexBot) error {
+			if !bot.ManageLambdaPermission {
+				return nil
+			}
+			return c.AddLambdaPermission(ctx, bot)
+		}},
 	}
+}
 
-	// put the archive containing intents and slots in s3
-	// (in a location determined by the aws lex sdk)
-	uploadId, err := c.upload(bot.ArchivePath)
+// CreateBot creates the bot, running the steps not in bot.CompletedSteps.
+// On error, bot.Id is set if the bot exists and CompletedSteps records how far
+// the creation got, so calling CreateBot again resumes from the failed step.
+func (c *AwsClient) CreateBot(ctx context.Context, bot *LexBot) error {
 
-	if err != nil {
-		return err
+	// fall back to the lex service-linked role when no role is given
+	if bot.IamRoleArn == "" {
+		err := c.EnsureServiceLinkedRole(ctx, bot)
+		if err != nil {
+			return err
+		}
 	}
 
-	// import the bot intents and slots into the bot
-	err = c.importBot(uploadId, *bot)
-
-	if err != nil {
-		return err
+	if bot.AdoptExisting && bot.Id == "" {
+		err := c.adoptBot(ctx, bot)
+		if err != nil {
+			return err
+		}
 	}
 
-	// set the version of the imported bot
-	err = c.setImportedVersion(bot)
+	for _, step := range c.createSteps() {
 
-	if err != nil {
-		return err
-	}
+		if bot.StepCompleted(step.name) {
+			log.Printf("[DEBUG] skipping completed create step %s\n", step.name)
+			continue
+		}
 
-	// update the original alias to reference the desired lambda
-	err = c.updateOriginalAlias(bot)
+		err := step.run(ctx, bot)
 
-	if err != nil {
-		return err
-	}
-
-	// build the bot
-	err = c.buildBot(bot)
+		if err != nil {
+			return fmt.Errorf("create step %s failed: %s", step.name, err)
+		}
 
-	if err != nil {
-		return err
+		bot.CompletedSteps = append(bot.CompletedSteps, step.name)
 	}
 
-	// create a new version for the imported bot
-	err = c.createVersion(bot)
+	return nil
+}
 
-	if err != nil {
-		return err
+func (bot *LexBot) StepCompleted(name string) bool {
+	for _, completed := range bot.CompletedSteps {
+		if completed == name {
+			return true
+		}
 	}
+	return false
+}
 
-	// create an alias to the new version whose name matches the
-	// alias defined in the tf bot resource
-	err = c.createAlias(bot)
-
-	if err != nil {
-		return err
+// CreateCompleted is false when a previous create failed part way through
+func (c *AwsClient) CreateCompleted(bot *LexBot) bool {
+	for _, step := range c.createSteps() {
+		if !bot.StepCompleted(step.name) {
+			return false
+		}
 	}
-
-	return err
+	return true
 }
 
-func (c *AwsClient) UpdateBot(bot *LexBot, d *schema.ResourceData) error {
+func (c *AwsClient) UpdateBot(ctx context.Context, bot *LexBot, d *schema.ResourceData) error {
 
 	var err error
 
 	if d.HasChange("name") || d.HasChange("description") || d.HasChange("iam_role") {
 
-		_, err := c.Client.UpdateBot(context.TODO(), &lexmodelsv2.UpdateBotInput{
+		_, err := c.Client.UpdateBot(ctx, &lexmodelsv2.UpdateBotInput{
 			BotId:   &bot.Id,
 			BotName: &bot.Name,
 			DataPrivacy: &types.DataPrivacy{
@@ -220,100 +304,179 @@ func (c *AwsClient) UpdateBot(bot *LexBot, d *schema.ResourceData) error {
 		}
 	}
 
-	if d.HasChange("source_code_hash") {
+	if d.HasChange("source_code_hash") || d.HasChange("qna") || d.HasChange("locale") {
 
-		// put the archive containing intents and slots in s3
-		// (in a location determined by the aws lex sdk)
-		uploadId, err := c.upload(bot.ArchivePath)
+		if d.HasChange("source_code_hash") || d.HasChange("qna") {
+
+			// put the archive containing intents and slots in s3
+			// (in a location determined by the aws lex sdk)
+			uploadId, err := c.uploadArchive(ctx, bot)
+
+			if err != nil {
+				return err
+			}
+
+			// import the bot intents and slots into the bot
+			err = c.importBot(ctx, uploadId, *bot)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		// re-apply locale overrides, since an import resets them
+		err = c.updateLocales(ctx, bot)
 
 		if err != nil {
 			return err
 		}
 
-		// import the bot intents and slots into the bot
-		err = c.importBot(uploadId, *bot)
+		// re-import the custom vocabularies, for the same reason
+		err = c.importCustomVocabularies(ctx, bot)
 
 		if err != nil {
 			return err
 		}
 
-		// create a new version for the imported bot
-		err = c.createVersion(bot)
+		// and delete those no longer configured
+		err = c.deleteRemovedVocabularies(ctx, bot)
 
 		if err != nil {
 			return err
 		}
 
-		// build the bot
-		err = c.buildBot(bot)
+		// build the bot, so the version picks up the changes
+		err = c.buildBot(ctx, bot)
+
+		if err != nil {
+			return err
+		}
+
+		// create a new version for the imported bot
+		err = c.createVersion(ctx, bot)
+
+		if err != nil {
+			return err
+		}
 	}
 
+	previousBot := *bot
+
 	// create or update alias for the bot
-	err = c.createOrUpdateAlias(bot)
+	err = c.createOrUpdateAlias(ctx, bot)
 
 	if err != nil {
 		return err
 	}
 
-	if d.HasChange("tags") {
-		// updated tags on alias
-		log.Printf("[DEBUG] adding tags to alias: %v\n", bot.Tags)
-		_, err = c.Client.TagResource(context.TODO(), &lexmodelsv2.TagResourceInput{
-			ResourceARN: getAddr(getAliasArn(bot.Id, bot.AliasId, c.Region, c.AccountId)),
-			Tags:        bot.Tags,
-		})
+	if d.HasChange("manage_lambda_permission") || d.HasChange("lambda_arn") || d.HasChange("alias") {
+
+		// remove the permission managed for the previous lambda and alias
+		oldManage, _ := d.GetChange("manage_lambda_permission")
+		oldLambdaArn, _ := d.GetChange("lambda_arn")
+
+		if oldManage.(bool) {
+			previousBot.LambdaArn = oldLambdaArn.(string)
+			err = c.RemoveLambdaPermission(ctx, &previousBot)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if bot.ManageLambdaPermission {
+		err = c.AddLambdaPermission(ctx, bot)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("tags") || d.HasChange("tags_all") || d.HasChange("alias_tags_match") {
+
+		// update tags on the bot and the alias
+		log.Printf("[DEBUG] updating tags of bot and alias: %v\n", bot.Tags)
+		err = c.updateTags(ctx, c.BotArn(bot.Id), bot.Tags)
+
+		if err != nil {
+			return err
+		}
+
+		err = c.updateTags(ctx, c.AliasArn(bot.Id, bot.AliasId), bot.Tags)
 	}
 
 	return err
 }
-func (c *AwsClient) createBot(bot *LexBot) error {
+func (c *AwsClient) createBot(ctx context.Context, bot *LexBot) error {
 
-	createBotOutput, err := c.Client.CreateBot(context.TODO(), &lexmodelsv2.CreateBotInput{
-		BotName: &bot.Name,
-		DataPrivacy: &types.DataPrivacy{
-			ChildDirected: false,
-		},
-		RoleArn:                 &bot.IamRoleArn,
-		Description:             &bot.Description,
-		IdleSessionTTLInSeconds: &ttl,
-	})
+	var err error
 
-	if err != nil {
-		return err
-	}
+	// when resuming, the bot may exist but not have become available yet
+	if bot.Id == "" {
 
-	bot.Id = *createBotOutput.BotId
+		var createBotOutput *lexmodelsv2.CreateBotOutput
+
+		createBotOutput, err = c.Client.CreateBot(ctx, &lexmodelsv2.CreateBotInput{
+			BotName: &bot.Name,
+			DataPrivacy: &types.DataPrivacy{
+				ChildDirected: false,
+			},
+			RoleArn:                 &bot.IamRoleArn,
+			Description:             &bot.Description,
+			IdleSessionTTLInSeconds: &ttl,
+			BotTags:                 bot.Tags,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		bot.Id = *createBotOutput.BotId
+	}
 
 	// wait for creation to complete
 	expiredTimeSec := 0
 	sleepDurationSec := 10
 	for {
-		botDescription, describeErr := c.Client.DescribeBot(context.TODO(),
+		botDescription, describeErr := c.Client.DescribeBot(ctx,
 			&lexmodelsv2.DescribeBotInput{
 				BotId: &bot.Id,
 			})
 
+		// stop if the operation is cancelled (e.g. on ctrl-c), which also
+		// fails the describe call
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		// break if creation is complete
 		if (describeErr == nil && botDescription.BotStatus == types.BotStatusAvailable) ||
 			expiredTimeSec >= BotWaitTimeoutSec {
 			break
 		}
 
-		log.Printf("[DEBUG] waiting for bot creation to complete. Current status: %s\n", botDescription.BotStatus)
+		if botDescription != nil {
+			log.Printf("[DEBUG] waiting for bot creation to complete. Current status: %s\n", botDescription.BotStatus)
+		} else {
+			log.Printf("[DEBUG] waiting for bot creation to complete. Current status: %s\n", "unknown")
+		}
 
-		// sleep for X seconds
-		time.Sleep(time.Duration(sleepDurationSec) * time.Second)
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return err
+		}
 		expiredTimeSec += sleepDurationSec
 	}
 
 	return err
 }
 
-func (c *AwsClient) importBot(uploadId string,
+func (c *AwsClient) importBot(ctx context.Context, uploadId string,
 	bot LexBot) error {
 
 	// import the archive
-	_, err := c.Client.StartImport(context.TODO(), &lexmodelsv2.StartImportInput{
+	_, err := c.Client.StartImport(ctx, &lexmodelsv2.StartImportInput{
 		ImportId:      &uploadId,
 		MergeStrategy: types.MergeStrategyOverwrite,
 		ResourceSpecification: &types.ImportResourceSpecification{
@@ -325,51 +488,96 @@ func (c *AwsClient) importBot(uploadId string,
 				RoleArn: &bot.IamRoleArn,
 			},
 		},
-	})
+	}, retryConflict)
 
 	if err != nil {
 		return err
 	}
 
-	// wait for import to complete
+	return c.importWait(ctx, uploadId)
+}
+
+// wait for an import to complete
+func (c *AwsClient) importWait(ctx context.Context, importId string) error {
+
+	var err error
+
 	expiredTimeSec := 0
 	sleepDurationSec := 10
 	for {
-		startImportOutput, err := c.Client.DescribeImport(context.TODO(), &lexmodelsv2.DescribeImportInput{
-			ImportId: &uploadId,
-		})
+		var describeImportOutput *lexmodelsv2.DescribeImportOutput
 
-		// log.Printf("[DEBUG] import output: %v\n", startImportOutput)
-		// log.Printf("[DEBUG] import status: %v\n", startImportOutput.ImportStatus)
+		describeImportOutput, err = c.Client.DescribeImport(ctx, &lexmodelsv2.DescribeImportInput{
+			ImportId: &importId,
+		})
 
 		// break if import is complete
-		if (err == nil && startImportOutput.ImportStatus == types.ImportStatusCompleted) ||
+		if (err == nil && describeImportOutput.ImportStatus == types.ImportStatusCompleted) ||
 			expiredTimeSec >= BotWaitTimeoutSec {
 			break
 		}
 
-		log.Printf("[DEBUG] waiting for bot import to complete. Current status: %s\n", startImportOutput.ImportStatus)
+		if err == nil && describeImportOutput.ImportStatus == types.ImportStatusFailed {
+			return fmt.Errorf("import %s failed: %v", importId, describeImportOutput.FailureReasons)
+		}
+
+		if err == nil {
+			log.Printf("[DEBUG] waiting for import to complete. Current status: %s\n", describeImportOutput.ImportStatus)
+		}
 
-		// sleep for X seconds
-		time.Sleep(time.Duration(sleepDurationSec) * time.Second)
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return err
+		}
 		expiredTimeSec += sleepDurationSec
 	}
 
 	return err
 }
 
-func (c *AwsClient) upload(archivePath string) (string, error) {
+// upload the archive of the bot, generating the question and answer
+// resources into it when the bot has any
+func (c *AwsClient) uploadArchive(ctx context.Context, bot *LexBot) (string, error) {
+
+	if len(bot.Qna) == 0 {
+		return c.upload(ctx, bot.ArchivePath)
+	}
+
+	b, err := QnaArchive(*bot)
+	if err != nil {
+		return "", fmt.Errorf("error generating qna archive: %s", err)
+	}
+
+	return c.uploadBytes(ctx, b)
+}
+
+func (c *AwsClient) upload(ctx context.Context, archivePath string) (string, error) {
+
+	b, err := ioutil.ReadFile(archivePath)
+	if err != nil {
+		return "", err
+	}
+
+	return c.uploadBytes(ctx, b)
+}
+
+// upload an archive to a pre-signed url, returning the id to import it with
+func (c *AwsClient) uploadBytes(ctx context.Context, b []byte) (string, error) {
 
 	var uploadId string
 
-	createUploadUrlOutput, err := c.Client.CreateUploadUrl(context.TODO(), &lexmodelsv2.CreateUploadUrlInput{})
+	createUploadUrlOutput, err := c.Client.CreateUploadUrl(ctx, &lexmodelsv2.CreateUploadUrlInput{})
 
 	if err != nil {
 		return uploadId, err
 	}
 
 	uploadId = *createUploadUrlOutput.ImportId
-	uploadUrl := *createUploadUrlOutput.UploadUrl
+	uploadUrl, err := c.uploadUrl(*createUploadUrlOutput.UploadUrl)
+
+	if err != nil {
+		return uploadId, err
+	}
 
 	// log.Printf("[DEBUG] create url id: %s, url: %s\n", *createUploadUrlOutput.ImportId, *createUploadUrlOutput.UploadUrl)
 
@@ -377,19 +585,18 @@ func (c *AwsClient) upload(archivePath string) (string, error) {
 		Timeout: time.Second * 10,
 	}
 
-	b, err := ioutil.ReadFile(archivePath)
+	req, err := http.NewRequestWithContext(ctx, "PUT", uploadUrl, bytes.NewReader(b))
 	if err != nil {
 		return uploadId, err
 	}
 
-	req, err := http.NewRequest("PUT", uploadUrl, bytes.NewReader(b))
+	contentType := http.DetectContentType(b)
+	req.Header.Set("Content-Type", contentType)
+	rsp, err := client.Do(req)
 	if err != nil {
 		return uploadId, err
 	}
-
-	contentType := http.DetectContentType(b)
-	req.Header.Set("Content-Type", contentType)
-	rsp, _ := client.Do(req)
+	defer rsp.Body.Close()
 
 	// log.Printf("[DEBUG] upload post content type %v\n", contentType)
 	// log.Printf("[DEBUG] upload post response %v\n", rsp)
@@ -400,9 +607,32 @@ func (c *AwsClient) upload(archivePath string) (string, error) {
 	return uploadId, nil
 }
 
-func (c *AwsClient) setImportedVersion(bot *LexBot) error {
+// point the pre-signed upload url at the custom upload endpoint, if any
+func (c *AwsClient) uploadUrl(presignedUrl string) (string, error) {
+
+	if c.Endpoints.Upload == "" {
+		return presignedUrl, nil
+	}
+
+	u, err := url.Parse(presignedUrl)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, err := url.Parse(c.Endpoints.Upload)
+	if err != nil {
+		return "", err
+	}
+
+	u.Scheme = endpoint.Scheme
+	u.Host = endpoint.Host
+
+	return u.String(), nil
+}
+
+func (c *AwsClient) setImportedVersion(ctx context.Context, bot *LexBot) error {
 
-	listBotVersionOutput, err := c.Client.ListBotVersions(context.TODO(), &lexmodelsv2.ListBotVersionsInput{
+	listBotVersionOutput, err := c.Client.ListBotVersions(ctx, &lexmodelsv2.ListBotVersionsInput{
 		BotId: &bot.Id,
 	})
 
@@ -412,16 +642,23 @@ func (c *AwsClient) setImportedVersion(bot *LexBot) error {
 
 	if listBotVersionOutput != nil && len(listBotVersionOutput.BotVersionSummaries) == 1 {
 		bot.Version = *listBotVersionOutput.BotVersionSummaries[0].BotVersion
-	} else {
-		err = fmt.Errorf("could not find bot version for imported bot: %s", bot.Id)
+		return nil
 	}
 
-	return err
+	// an adopted bot also has the versions of earlier runs
+	for _, summary := range listBotVersionOutput.BotVersionSummaries {
+		if summary.BotVersion != nil && *summary.BotVersion == DraftVersion {
+			bot.Version = DraftVersion
+			return nil
+		}
+	}
+
+	return fmt.Errorf("could not find bot version for imported bot: %s", bot.Id)
 }
 
-func (c *AwsClient) updateOriginalAlias(bot *LexBot) error {
+func (c *AwsClient) updateOriginalAlias(ctx context.Context, bot *LexBot) error {
 
-	listBotAliasOutput, err := c.Client.ListBotAliases(context.TODO(), &lexmodelsv2.ListBotAliasesInput{
+	listBotAliasOutput, err := c.Client.ListBotAliases(ctx, &lexmodelsv2.ListBotAliasesInput{
 		BotId: &bot.Id,
 	})
 
@@ -436,44 +673,48 @@ func (c *AwsClient) updateOriginalAlias(bot *LexBot) error {
 		ogAliasId = *listBotAliasOutput.BotAliasSummaries[0].BotAliasId
 		ogAliasName = *listBotAliasOutput.BotAliasSummaries[0].BotAliasName
 	} else {
-		err = fmt.Errorf("could not find bot alias for imported bot: %s", bot.Id)
-		return err
+		// an adopted bot also has the aliases of earlier runs
+		for _, summary := range listBotAliasOutput.BotAliasSummaries {
+			if summary.BotAliasName != nil && *summary.BotAliasName == OriginalAliasName {
+				ogAliasId = *summary.BotAliasId
+				ogAliasName = *summary.BotAliasName
+			}
+		}
+	}
+
+	if ogAliasId == "" {
+		return fmt.Errorf("could not find bot alias for imported bot: %s", bot.Id)
 	}
 
 	// update the alias to point to the lambda function
-	_, err = c.Client.UpdateBotAlias(context.TODO(), &lexmodelsv2.UpdateBotAliasInput{
-		BotId:        &bot.Id,
-		BotAliasId:   &ogAliasId,
-		BotAliasName: &ogAliasName,
-		BotVersion:   &bot.Version,
-		BotAliasLocaleSettings: map[string]types.BotAliasLocaleSettings{
-			"en_US": {
-				CodeHookSpecification: &types.CodeHookSpecification{
-					LambdaCodeHook: &types.LambdaCodeHook{
-						LambdaARN:                &bot.LambdaArn,
-						CodeHookInterfaceVersion: getAddr("1.0"),
-					},
-				},
-				Enabled: true,
-			},
-		},
+	_, err = c.Client.UpdateBotAlias(ctx, &lexmodelsv2.UpdateBotAliasInput{
+		BotId:                  &bot.Id,
+		BotAliasId:             &ogAliasId,
+		BotAliasName:           &ogAliasName,
+		BotVersion:             &bot.Version,
+		BotAliasLocaleSettings: bot.aliasLocaleSettings(),
 	})
 
 	return err
 }
 
-func (c *AwsClient) createVersion(bot *LexBot) error {
+func (c *AwsClient) createVersion(ctx context.Context, bot *LexBot) error {
+
+	// versions are always created from the draft, which holds the latest
+	// import and locale overrides
+	localeSpecification := make(map[string]types.BotVersionLocaleDetails)
+	for _, localeId := range bot.localeIds() {
+		localeSpecification[localeId] = types.BotVersionLocaleDetails{
+			SourceBotVersion: getAddr(DraftVersion),
+		}
+	}
 
-	createBotVersionOutput, err := c.Client.CreateBotVersion(context.TODO(), &lexmodelsv2.CreateBotVersionInput{
+	createBotVersionOutput, err := c.Client.CreateBotVersion(ctx, &lexmodelsv2.CreateBotVersionInput{
 		BotId: &bot.Id,
 		// use the description field to store the source code hash
-		Description: &bot.SourceCodeHash,
-		BotVersionLocaleSpecification: map[string]types.BotVersionLocaleDetails{
-			"en_US": {
-				SourceBotVersion: getAddr(bot.Version),
-			},
-		},
-	})
+		Description:                   &bot.SourceCodeHash,
+		BotVersionLocaleSpecification: localeSpecification,
+	}, retryConflict, retryPreconditionFailed)
 
 	if err != nil {
 		return err
@@ -485,7 +726,7 @@ func (c *AwsClient) createVersion(bot *LexBot) error {
 	expiredTimeSec := 0
 	sleepDurationSec := 10
 	for {
-		describeBotVersionOutput, err := c.Client.DescribeBotVersion(context.TODO(), &lexmodelsv2.DescribeBotVersionInput{
+		describeBotVersionOutput, err := c.Client.DescribeBotVersion(ctx, &lexmodelsv2.DescribeBotVersionInput{
 			BotId:      &bot.Id,
 			BotVersion: &bot.Version,
 		})
@@ -502,22 +743,38 @@ func (c *AwsClient) createVersion(bot *LexBot) error {
 			log.Printf("[DEBUG] waiting for bot version to become available. Current status: %s\n", "unknown")
 		}
 
-		// sleep for X seconds
-		time.Sleep(time.Duration(sleepDurationSec) * time.Second)
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return err
+		}
 		expiredTimeSec += sleepDurationSec
 	}
 
 	return err
 }
 
-func (c *AwsClient) buildBot(bot *LexBot) error {
+func (c *AwsClient) buildBot(ctx context.Context, bot *LexBot) error {
+
+	for _, localeId := range bot.localeIds() {
+
+		err := c.buildBotLocale(ctx, bot, localeId)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *AwsClient) buildBotLocale(ctx context.Context, bot *LexBot, localeId string) error {
 
-	_, err := c.Client.BuildBotLocale(context.TODO(), &lexmodelsv2.BuildBotLocaleInput{
+	_, err := c.Client.BuildBotLocale(ctx, &lexmodelsv2.BuildBotLocaleInput{
 		BotId: &bot.Id,
 		// The version of the bot to build can only be the draft version
 		BotVersion: getAddr(DraftVersion),
-		LocaleId:   getAddr("en_US"),
-	})
+		LocaleId:   &localeId,
+	}, retryConflict, retryPreconditionFailed)
 
 	if err != nil {
 		return err
@@ -527,10 +784,10 @@ func (c *AwsClient) buildBot(bot *LexBot) error {
 	expiredTimeSec := 0
 	sleepDurationSec := 10
 	for {
-		describeBotLocaleOutput, err := c.Client.DescribeBotLocale(context.TODO(), &lexmodelsv2.DescribeBotLocaleInput{
+		describeBotLocaleOutput, err := c.Client.DescribeBotLocale(ctx, &lexmodelsv2.DescribeBotLocaleInput{
 			BotId:      &bot.Id,
 			BotVersion: getAddr(DraftVersion),
-			LocaleId:   getAddr("en_US"),
+			LocaleId:   &localeId,
 		})
 
 		// break if version is available
@@ -540,57 +797,49 @@ func (c *AwsClient) buildBot(bot *LexBot) error {
 		}
 
 		if describeBotLocaleOutput != nil {
-			log.Printf("[DEBUG] waiting for bot build to complete. Current status: %s\n", describeBotLocaleOutput.BotLocaleStatus)
+			log.Printf("[DEBUG] waiting for bot build of %s to complete. Current status: %s\n", localeId, describeBotLocaleOutput.BotLocaleStatus)
 		} else {
-			log.Printf("[DEBUG] waiting for bot build to complete. Current status: %s\n", "unknown")
+			log.Printf("[DEBUG] waiting for bot build of %s to complete. Current status: %s\n", localeId, "unknown")
 		}
 
-		// sleep for X seconds
-		time.Sleep(time.Duration(sleepDurationSec) * time.Second)
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return err
+		}
 		expiredTimeSec += sleepDurationSec
 	}
 
 	return err
 }
 
-func (c *AwsClient) createOrUpdateAlias(bot *LexBot) error {
+func (c *AwsClient) createOrUpdateAlias(ctx context.Context, bot *LexBot) error {
 
 	// see if the alias already exists
-	aliasId, err := c.getAliasId(bot, bot.Alias)
+	aliasId, err := c.getAliasId(ctx, bot, bot.Alias)
 
 	if err != nil {
 		return err
 	}
 
 	if aliasId == "" {
-		err = c.createAlias(bot)
+		err = c.createAlias(ctx, bot)
 	} else {
 		bot.AliasId = aliasId
-		err = c.updateAlias(bot)
+		err = c.updateAlias(ctx, bot)
 	}
 
 	return err
 }
 
-func (c *AwsClient) updateAlias(bot *LexBot) error {
+func (c *AwsClient) updateAlias(ctx context.Context, bot *LexBot) error {
 
 	// update the existing alias to reference the bot version
-	_, err := c.Client.UpdateBotAlias(context.TODO(), &lexmodelsv2.UpdateBotAliasInput{
-		BotId:        &bot.Id,
-		BotAliasId:   &bot.AliasId,
-		BotAliasName: &bot.Alias,
-		BotVersion:   &bot.Version,
-		BotAliasLocaleSettings: map[string]types.BotAliasLocaleSettings{
-			"en_US": {
-				CodeHookSpecification: &types.CodeHookSpecification{
-					LambdaCodeHook: &types.LambdaCodeHook{
-						LambdaARN:                &bot.LambdaArn,
-						CodeHookInterfaceVersion: getAddr("1.0"),
-					},
-				},
-				Enabled: true,
-			},
-		},
+	_, err := c.Client.UpdateBotAlias(ctx, &lexmodelsv2.UpdateBotAliasInput{
+		BotId:                  &bot.Id,
+		BotAliasId:             &bot.AliasId,
+		BotAliasName:           &bot.Alias,
+		BotVersion:             &bot.Version,
+		BotAliasLocaleSettings: bot.aliasLocaleSettings(),
 	})
 
 	if err != nil {
@@ -598,12 +847,10 @@ func (c *AwsClient) updateAlias(bot *LexBot) error {
 	}
 
 	// wait for the alias to become available
-	c.aliasWait(bot)
-
-	return err
+	return c.aliasWait(ctx, bot)
 }
 
-func (c *AwsClient) createAlias(bot *LexBot) error {
+func (c *AwsClient) createAlias(ctx context.Context, bot *LexBot) error {
 
 	botTags := make(map[string]string)
 	for key, val := range bot.Tags {
@@ -611,22 +858,12 @@ func (c *AwsClient) createAlias(bot *LexBot) error {
 	}
 
 	// create the alias
-	createBotAliasOutput, err := c.Client.CreateBotAlias(context.TODO(), &lexmodelsv2.CreateBotAliasInput{
-		BotId:        &bot.Id,
-		BotAliasName: &bot.Alias,
-		BotVersion:   &bot.Version,
-		Tags:         botTags,
-		BotAliasLocaleSettings: map[string]types.BotAliasLocaleSettings{
-			"en_US": {
-				CodeHookSpecification: &types.CodeHookSpecification{
-					LambdaCodeHook: &types.LambdaCodeHook{
-						LambdaARN:                &bot.LambdaArn,
-						CodeHookInterfaceVersion: getAddr("1.0"),
-					},
-				},
-				Enabled: true,
-			},
-		},
+	createBotAliasOutput, err := c.Client.CreateBotAlias(ctx, &lexmodelsv2.CreateBotAliasInput{
+		BotId:                  &bot.Id,
+		BotAliasName:           &bot.Alias,
+		BotVersion:             &bot.Version,
+		Tags:                   botTags,
+		BotAliasLocaleSettings: bot.aliasLocaleSettings(),
 	})
 
 	if err != nil {
@@ -637,17 +874,15 @@ func (c *AwsClient) createAlias(bot *LexBot) error {
 	bot.AliasId = *createBotAliasOutput.BotAliasId
 
 	// wait for the alias to become available
-	c.aliasWait(bot)
-
-	return err
+	return c.aliasWait(ctx, bot)
 }
 
-func (c *AwsClient) aliasWait(bot *LexBot) {
+func (c *AwsClient) aliasWait(ctx context.Context, bot *LexBot) error {
 	// wait for bot alias to be available
 	expiredTimeSec := 0
 	sleepDurationSec := 10
 	for {
-		describeBotAliasOutput, describeErr := c.Client.DescribeBotAlias(context.TODO(),
+		describeBotAliasOutput, describeErr := c.Client.DescribeBotAlias(ctx,
 			&lexmodelsv2.DescribeBotAliasInput{
 				BotId:      &bot.Id,
 				BotAliasId: &bot.AliasId,
@@ -665,14 +900,18 @@ func (c *AwsClient) aliasWait(bot *LexBot) {
 			log.Printf("[DEBUG] waiting for bot alias to be available. Current status: %s\n", "unknown")
 		}
 
-		// sleep for X seconds
-		time.Sleep(time.Duration(sleepDurationSec) * time.Second)
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return err
+		}
 		expiredTimeSec += sleepDurationSec
 	}
+
+	return nil
 }
 
-func (c *AwsClient) getAliasId(bot *LexBot, alias string) (string, error) {
-	botAlias, err := c.Client.ListBotAliases(context.TODO(),
+func (c *AwsClient) getAliasId(ctx context.Context, bot *LexBot, alias string) (string, error) {
+	botAlias, err := c.Client.ListBotAliases(ctx,
 		&lexmodelsv2.ListBotAliasesInput{
 			BotId: &bot.Id,
 		})
@@ -689,9 +928,9 @@ func (c *AwsClient) getAliasId(bot *LexBot, alias string) (string, error) {
 	return "", err
 }
 
-func (c *AwsClient) DeleteBot(botId string) error {
+func (c *AwsClient) DeleteBot(ctx context.Context, botId string) error {
 
-	_, err := c.Client.DeleteBot(context.TODO(), &lexmodelsv2.DeleteBotInput{
+	_, err := c.Client.DeleteBot(ctx, &lexmodelsv2.DeleteBotInput{
 		BotId:                  &botId,
 		SkipResourceInUseCheck: true,
 	})
@@ -704,7 +943,7 @@ func (c *AwsClient) DeleteBot(botId string) error {
 	expiredTimeSec := 0
 	sleepDurationSec := 10
 	for {
-		botDescription, describeErr := c.Client.DescribeBot(context.TODO(),
+		botDescription, describeErr := c.Client.DescribeBot(ctx,
 			&lexmodelsv2.DescribeBotInput{
 				BotId: &botId,
 			})
@@ -722,18 +961,30 @@ func (c *AwsClient) DeleteBot(botId string) error {
 			break
 		}
 
-		// sleep for X seconds
-		time.Sleep(time.Duration(sleepDurationSec) * time.Second)
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return err
+		}
 		expiredTimeSec += sleepDurationSec
 	}
 
 	return err
 }
 
-func getAddr(s string) *string {
-	return &s
+// sleepContext sleeps for the given duration, or until the context is
+// cancelled (e.g. on ctrl-c), in which case the context error is returned
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
-func getAliasArn(botId string, aliasId string, accountId string, region string) string {
-	return fmt.Sprintf("arn:aws:lex:%s:%s:bot-alias/%s/%s", accountId, region, botId, aliasId)
+func getAddr(s string) *string {
+	return &s
 }
@@ -1,8 +1,11 @@
 package aws_client
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
 	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
@@ -47,7 +50,7 @@ func TestGetBot(t *testing.T) {
 		err: nil,
 	})
 
-	bot, err := awsClient.GetBot(aliasId, aliasName)
+	bot, err := awsClient.GetBot(context.TODO(), aliasId, aliasName)
 
 	if err != nil {
 		t.Log("error should be nil", err)
@@ -56,3 +59,160 @@ func TestGetBot(t *testing.T) {
 
 	fmt.Printf("%+v\n", bot)
 }
+
+func TestUploadUrl(t *testing.T) {
+
+	presignedUrl := "https://some-bucket.s3.us-west-2.amazonaws.com/some-key?X-Amz-Signature=abc"
+
+	awsClient, _ := NewTestClient(MockBotClient{})
+
+	uploadUrl, err := awsClient.uploadUrl(presignedUrl)
+
+	if err != nil || uploadUrl != presignedUrl {
+		t.Errorf("expected url to be unchanged, got: %s, err: %v", uploadUrl, err)
+	}
+
+	awsClient.Endpoints.Upload = "http://localhost:4566"
+
+	uploadUrl, err = awsClient.uploadUrl(presignedUrl)
+
+	if err != nil || uploadUrl != "http://localhost:4566/some-key?X-Amz-Signature=abc" {
+		t.Errorf("expected url to use the upload endpoint, got: %s, err: %v", uploadUrl, err)
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := sleepContext(ctx, time.Minute)
+
+	if err != context.Canceled {
+		t.Errorf("expected the sleep to be cancelled, got: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("expected the sleep to stop when cancelled")
+	}
+
+	if err = sleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("expected the sleep to complete, got: %v", err)
+	}
+
+	// waiting for a bot stops when cancelled
+	awsClient, _ := NewTestClient(MockBotClient{})
+	bot := LexBot{Id: "BOTID"}
+
+	if err = awsClient.createBot(ctx, &bot); err != context.Canceled {
+		t.Errorf("expected the wait for the bot to be cancelled, got: %v", err)
+	}
+}
+
+func TestCreateBotResume(t *testing.T) {
+
+	// any call to aws fails
+	awsClient, _ := NewTestClient(MockBotClient{
+		err: fmt.Errorf("service unavailable"),
+	})
+
+	bot := LexBot{
+		Id:         "BOTID",
+		IamRoleArn: "some-arn",
+		CompletedSteps: []string{StepCreateBot, StepImport, StepOriginalAlias,
+			StepLocales, StepBuild, StepVersion},
+	}
+
+	if awsClient.CreateCompleted(&bot) {
+		t.Errorf("expected create to be incomplete")
+	}
+
+	// resuming starts at the alias step, which fails
+	err := awsClient.CreateBot(context.TODO(), &bot)
+
+	if err == nil || !strings.Contains(err.Error(), StepAlias) {
+		t.Errorf("expected the alias step to fail, got: %v", err)
+	}
+	if len(bot.CompletedSteps) != 6 {
+		t.Errorf("expected the completed steps to be unchanged, got: %v", bot.CompletedSteps)
+	}
+
+	// nothing is left to do once all steps completed
+	bot.CompletedSteps = append(bot.CompletedSteps, StepAlias, StepLambdaPermission)
+
+	if err = awsClient.CreateBot(context.TODO(), &bot); err != nil {
+		t.Errorf("expected no calls to aws, got: %v", err)
+	}
+	if !awsClient.CreateCompleted(&bot) {
+		t.Errorf("expected create to be complete")
+	}
+}
+
+func TestQnaLocales(t *testing.T) {
+
+	var inputs []interface{}
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		DescribeBotLocaleOutput: lexmodelsv2.DescribeBotLocaleOutput{
+			BotLocaleStatus: types.BotLocaleStatusBuilt,
+		},
+		CreateBotVersionOutput: lexmodelsv2.CreateBotVersionOutput{
+			BotVersion: getAddr("2"),
+		},
+		DescribeBotVersionOutput: lexmodelsv2.DescribeBotVersionOutput{
+			BotStatus: types.BotStatusAvailable,
+		},
+		DescribeBotAliasOutput: lexmodelsv2.DescribeBotAliasOutput{
+			BotAliasStatus: types.BotAliasStatusAvailable,
+		},
+		inputs: &inputs,
+	})
+
+	// fr_CA only has qna pairs, no locale block
+	bot := LexBot{
+		Id:        "BOTID",
+		Alias:     "live",
+		AliasId:   "ALIASID",
+		LambdaArn: "some-lambda-arn",
+		Locales:   []LexBotLocale{{LocaleId: "es_US"}},
+		Qna: []LexQna{
+			{"gas-leak", "en_US", []string{"a"}, "a"},
+			{"gas-leak", "fr_CA", []string{"b"}, "b"},
+			{"gas-leak", "es_US", []string{"c"}, "c"},
+		},
+	}
+
+	for _, step := range []func(context.Context, *LexBot) error{awsClient.buildBot, awsClient.createVersion, awsClient.updateAlias} {
+		if err := step(context.TODO(), &bot); err != nil {
+			t.Fatalf("error should be nil: %s", err)
+		}
+	}
+
+	built := []string{}
+	var versionLocales map[string]types.BotVersionLocaleDetails
+	var aliasLocales map[string]types.BotAliasLocaleSettings
+
+	for _, input := range inputs {
+		switch input := input.(type) {
+		case *lexmodelsv2.BuildBotLocaleInput:
+			built = append(built, *input.LocaleId)
+		case *lexmodelsv2.CreateBotVersionInput:
+			versionLocales = input.BotVersionLocaleSpecification
+		case *lexmodelsv2.UpdateBotAliasInput:
+			aliasLocales = input.BotAliasLocaleSettings
+		}
+	}
+
+	if strings.Join(built, ",") != "en_US,es_US,fr_CA" {
+		t.Errorf("unexpected locales built: %v", built)
+	}
+
+	if len(versionLocales) != 3 || *versionLocales["fr_CA"].SourceBotVersion != DraftVersion {
+		t.Errorf("unexpected version locales: %v", versionLocales)
+	}
+
+	if settings, ok := aliasLocales["fr_CA"]; len(aliasLocales) != 3 || !ok || !settings.Enabled ||
+		*settings.CodeHookSpecification.LambdaCodeHook.LambdaARN != "some-lambda-arn" {
+		t.Errorf("expected the lambda to be enabled for every locale, got: %v", aliasLocales)
+	}
+}
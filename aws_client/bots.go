@@ -0,0 +1,115 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+type LexBotSummary struct {
+	Id          string
+	Name        string
+	Description string
+	Status      string
+	LastUpdated time.Time
+}
+
+// filters of ListBots. empty fields match every bot
+type LexBotFilter struct {
+	NamePrefix string
+	Status     string
+	// bots must have all of these tags
+	Tags map[string]string
+}
+
+// BotStatuses returns the possible statuses of a bot
+func BotStatuses() []string {
+	statuses := []string{}
+	for _, status := range types.BotStatus("").Values() {
+		statuses = append(statuses, string(status))
+	}
+	return statuses
+}
+
+// ListBots returns the bots of the account (in the client region) matching
+// the filter
+func (c *AwsClient) ListBots(ctx context.Context, filter LexBotFilter) ([]LexBotSummary, error) {
+
+	input := &lexmodelsv2.ListBotsInput{}
+
+	// lex only filters names on equality or containment, so narrow the list
+	// down to names containing the prefix and check the prefix below
+	if filter.NamePrefix != "" {
+		input.Filters = []types.BotFilter{
+			{
+				Name:     types.BotFilterNameBotName,
+				Operator: types.BotFilterOperatorContains,
+				Values:   []string{filter.NamePrefix},
+			},
+		}
+	}
+
+	bots := []LexBotSummary{}
+
+	paginator := lexmodelsv2.NewListBotsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+
+		listBotsOutput, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("error listing bots: %s", err)
+		}
+
+		for _, summary := range listBotsOutput.BotSummaries {
+
+			bot := LexBotSummary{
+				Id:          aws.ToString(summary.BotId),
+				Name:        aws.ToString(summary.BotName),
+				Description: aws.ToString(summary.Description),
+				Status:      string(summary.BotStatus),
+				LastUpdated: aws.ToTime(summary.LastUpdatedDateTime),
+			}
+
+			if !strings.HasPrefix(bot.Name, filter.NamePrefix) {
+				continue
+			}
+
+			if filter.Status != "" && bot.Status != filter.Status {
+				continue
+			}
+
+			if len(filter.Tags) > 0 {
+
+				// tags are not part of the summary, so only list them when filtering
+				tags, err := c.listTags(ctx, c.BotArn(bot.Id))
+
+				if err != nil {
+					return nil, fmt.Errorf("error listing tags of bot %s: %s", bot.Id, err)
+				}
+
+				if !hasTags(tags, filter.Tags) {
+					continue
+				}
+			}
+
+			bots = append(bots, bot)
+		}
+	}
+
+	return bots, nil
+}
+
+func hasTags(tags map[string]string, expectedTags map[string]string) bool {
+	for key, val := range expectedTags {
+		if tagVal, ok := tags[key]; !ok || tagVal != val {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package aws_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestListBots(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		ListBotsOutput: lexmodelsv2.ListBotsOutput{
+			BotSummaries: []types.BotSummary{
+				{BotId: getAddr("BOT1"), BotName: getAddr("qnabot-main"), BotStatus: types.BotStatusAvailable},
+				{BotId: getAddr("BOT2"), BotName: getAddr("qnabot-feature"), BotStatus: types.BotStatusFailed},
+				{BotId: getAddr("BOT3"), BotName: getAddr("other-qnabot"), BotStatus: types.BotStatusAvailable},
+			},
+		},
+		ListTagsForResourceOutput: lexmodelsv2.ListTagsForResourceOutput{
+			Tags: map[string]string{"unit": "shcva"},
+		},
+	})
+
+	tests := []struct {
+		filter LexBotFilter
+		ids    []string
+	}{
+		{LexBotFilter{}, []string{"BOT1", "BOT2", "BOT3"}},
+		{LexBotFilter{NamePrefix: "qnabot-"}, []string{"BOT1", "BOT2"}},
+		{LexBotFilter{NamePrefix: "qnabot-", Status: "Available"}, []string{"BOT1"}},
+		{LexBotFilter{Tags: map[string]string{"unit": "shcva"}}, []string{"BOT1", "BOT2", "BOT3"}},
+		{LexBotFilter{Tags: map[string]string{"unit": "scgma"}}, []string{}},
+	}
+
+	for _, test := range tests {
+
+		bots, err := awsClient.ListBots(context.TODO(), test.filter)
+
+		if err != nil {
+			t.Errorf("expected no error for filter %+v, got: %v", test.filter, err)
+			continue
+		}
+
+		ids := []string{}
+		for _, bot := range bots {
+			ids = append(ids, bot.Id)
+		}
+
+		if len(ids) != len(test.ids) {
+			t.Errorf("expected bots %v for filter %+v, got: %v", test.ids, test.filter, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != test.ids[i] {
+				t.Errorf("expected bots %v for filter %+v, got: %v", test.ids, test.filter, ids)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+)
+
+// a built-in intent or slot type, e.g. AMAZON.FallbackIntent
+type LexBuiltIn struct {
+	// the signature is used as the parent of a derived intent or slot type
+	Signature   string
+	Description string
+}
+
+// ListBuiltInIntents returns the built-in intents available in the locale
+func (c *AwsClient) ListBuiltInIntents(ctx context.Context, localeId string) ([]LexBuiltIn, error) {
+
+	intents := []LexBuiltIn{}
+
+	paginator := lexmodelsv2.NewListBuiltInIntentsPaginator(c.Client, &lexmodelsv2.ListBuiltInIntentsInput{
+		LocaleId: &localeId,
+	})
+
+	for paginator.HasMorePages() {
+
+		listBuiltInIntentsOutput, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("error listing built-in intents of locale %s: %s", localeId, err)
+		}
+
+		for _, summary := range listBuiltInIntentsOutput.BuiltInIntentSummaries {
+			intents = append(intents, LexBuiltIn{
+				Signature:   aws.ToString(summary.IntentSignature),
+				Description: aws.ToString(summary.Description),
+			})
+		}
+	}
+
+	return intents, nil
+}
+
+// ListBuiltInSlotTypes returns the built-in slot types available in the locale
+func (c *AwsClient) ListBuiltInSlotTypes(ctx context.Context, localeId string) ([]LexBuiltIn, error) {
+
+	slotTypes := []LexBuiltIn{}
+
+	paginator := lexmodelsv2.NewListBuiltInSlotTypesPaginator(c.Client, &lexmodelsv2.ListBuiltInSlotTypesInput{
+		LocaleId: &localeId,
+	})
+
+	for paginator.HasMorePages() {
+
+		listBuiltInSlotTypesOutput, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("error listing built-in slot types of locale %s: %s", localeId, err)
+		}
+
+		for _, summary := range listBuiltInSlotTypesOutput.BuiltInSlotTypeSummaries {
+			slotTypes = append(slotTypes, LexBuiltIn{
+				Signature:   aws.ToString(summary.SlotTypeSignature),
+				Description: aws.ToString(summary.Description),
+			})
+		}
+	}
+
+	return slotTypes, nil
+}
@@ -0,0 +1,55 @@
+package aws_client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestListBuiltInIntents(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		ListBuiltInIntentsOutput: lexmodelsv2.ListBuiltInIntentsOutput{
+			BuiltInIntentSummaries: []types.BuiltInIntentSummary{
+				{IntentSignature: getAddr("AMAZON.FallbackIntent"), Description: getAddr("Default fallback intent")},
+				{IntentSignature: getAddr("AMAZON.HelpIntent")},
+			},
+		},
+	})
+
+	intents, err := awsClient.ListBuiltInIntents(context.TODO(), "es_US")
+
+	if err != nil || len(intents) != 2 {
+		t.Fatalf("expected 2 intents, got: %v, err: %v", intents, err)
+	}
+
+	if intents[0].Signature != "AMAZON.FallbackIntent" || intents[0].Description != "Default fallback intent" {
+		t.Errorf("unexpected intent: %+v", intents[0])
+	}
+}
+
+func TestListBuiltInSlotTypes(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		ListBuiltInSlotTypesOutput: lexmodelsv2.ListBuiltInSlotTypesOutput{
+			BuiltInSlotTypeSummaries: []types.BuiltInSlotTypeSummary{
+				{SlotTypeSignature: getAddr("AMAZON.Number")},
+			},
+		},
+	})
+
+	slotTypes, err := awsClient.ListBuiltInSlotTypes(context.TODO(), "fr_CA")
+
+	if err != nil || len(slotTypes) != 1 || slotTypes[0].Signature != "AMAZON.Number" {
+		t.Fatalf("unexpected slot types: %v, err: %v", slotTypes, err)
+	}
+
+	awsClient, _ = NewTestClient(MockBotClient{err: errors.New("some error")})
+
+	if _, err := awsClient.ListBuiltInSlotTypes(context.TODO(), "fr_CA"); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -2,11 +2,18 @@ package aws_client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
@@ -15,6 +22,7 @@ type BotClient interface {
 	CreateBot(ctx context.Context, params *lexmodelsv2.CreateBotInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateBotOutput, error)
 	UpdateBot(ctx context.Context, params *lexmodelsv2.UpdateBotInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UpdateBotOutput, error)
 	DescribeBot(ctx context.Context, params *lexmodelsv2.DescribeBotInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeBotOutput, error)
+	ListBots(ctx context.Context, params *lexmodelsv2.ListBotsInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBotsOutput, error)
 	ListBotAliases(ctx context.Context, params *lexmodelsv2.ListBotAliasesInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBotAliasesOutput, error)
 	CreateBotAlias(ctx context.Context, params *lexmodelsv2.CreateBotAliasInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateBotAliasOutput, error)
 	CreateBotVersion(ctx context.Context, params *lexmodelsv2.CreateBotVersionInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateBotVersionOutput, error)
@@ -22,70 +30,305 @@ type BotClient interface {
 	DescribeBotAlias(ctx context.Context, params *lexmodelsv2.DescribeBotAliasInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeBotAliasOutput, error)
 	CreateUploadUrl(ctx context.Context, params *lexmodelsv2.CreateUploadUrlInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateUploadUrlOutput, error)
 	StartImport(ctx context.Context, params *lexmodelsv2.StartImportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.StartImportOutput, error)
+	ListBotLocales(ctx context.Context, params *lexmodelsv2.ListBotLocalesInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBotLocalesOutput, error)
+	CreateExport(ctx context.Context, params *lexmodelsv2.CreateExportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateExportOutput, error)
+	DescribeExport(ctx context.Context, params *lexmodelsv2.DescribeExportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeExportOutput, error)
+	DeleteExport(ctx context.Context, params *lexmodelsv2.DeleteExportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DeleteExportOutput, error)
+	ListBuiltInIntents(ctx context.Context, params *lexmodelsv2.ListBuiltInIntentsInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBuiltInIntentsOutput, error)
+	ListBuiltInSlotTypes(ctx context.Context, params *lexmodelsv2.ListBuiltInSlotTypesInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBuiltInSlotTypesOutput, error)
+	ListAggregatedUtterances(ctx context.Context, params *lexmodelsv2.ListAggregatedUtterancesInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListAggregatedUtterancesOutput, error)
+	StartBotRecommendation(ctx context.Context, params *lexmodelsv2.StartBotRecommendationInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.StartBotRecommendationOutput, error)
+	DescribeBotRecommendation(ctx context.Context, params *lexmodelsv2.DescribeBotRecommendationInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeBotRecommendationOutput, error)
+	StopBotRecommendation(ctx context.Context, params *lexmodelsv2.StopBotRecommendationInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.StopBotRecommendationOutput, error)
+	DeleteCustomVocabulary(ctx context.Context, params *lexmodelsv2.DeleteCustomVocabularyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DeleteCustomVocabularyOutput, error)
+	DescribeCustomVocabularyMetadata(ctx context.Context, params *lexmodelsv2.DescribeCustomVocabularyMetadataInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeCustomVocabularyMetadataOutput, error)
 	DescribeImport(ctx context.Context, params *lexmodelsv2.DescribeImportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeImportOutput, error)
 	DeleteBot(ctx context.Context, params *lexmodelsv2.DeleteBotInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DeleteBotOutput, error)
 	ListBotVersions(ctx context.Context, params *lexmodelsv2.ListBotVersionsInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBotVersionsOutput, error)
 	UpdateBotAlias(ctx context.Context, params *lexmodelsv2.UpdateBotAliasInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UpdateBotAliasOutput, error)
 	BuildBotLocale(ctx context.Context, params *lexmodelsv2.BuildBotLocaleInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.BuildBotLocaleOutput, error)
 	DescribeBotLocale(ctx context.Context, params *lexmodelsv2.DescribeBotLocaleInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeBotLocaleOutput, error)
+	UpdateBotLocale(ctx context.Context, params *lexmodelsv2.UpdateBotLocaleInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UpdateBotLocaleOutput, error)
 	ListTagsForResource(ctx context.Context, params *lexmodelsv2.ListTagsForResourceInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListTagsForResourceOutput, error)
 	TagResource(ctx context.Context, params *lexmodelsv2.TagResourceInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.TagResourceOutput, error)
+	UntagResource(ctx context.Context, params *lexmodelsv2.UntagResourceInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UntagResourceOutput, error)
+	CreateResourcePolicy(ctx context.Context, params *lexmodelsv2.CreateResourcePolicyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateResourcePolicyOutput, error)
+	UpdateResourcePolicy(ctx context.Context, params *lexmodelsv2.UpdateResourcePolicyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UpdateResourcePolicyOutput, error)
+	DeleteResourcePolicy(ctx context.Context, params *lexmodelsv2.DeleteResourcePolicyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DeleteResourcePolicyOutput, error)
+	DescribeResourcePolicy(ctx context.Context, params *lexmodelsv2.DescribeResourcePolicyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeResourcePolicyOutput, error)
+}
+
+// the subset of the lambda api used to manage the invoke permission of
+// the fulfillment lambda
+type LambdaClient interface {
+	AddPermission(ctx context.Context, params *lambda.AddPermissionInput, optFns ...func(*lambda.Options)) (*lambda.AddPermissionOutput, error)
+	RemovePermission(ctx context.Context, params *lambda.RemovePermissionInput, optFns ...func(*lambda.Options)) (*lambda.RemovePermissionOutput, error)
+	GetPolicy(ctx context.Context, params *lambda.GetPolicyInput, optFns ...func(*lambda.Options)) (*lambda.GetPolicyOutput, error)
+}
+
+// the subset of the iam api used to manage the lex service-linked role
+type IamClient interface {
+	GetRole(ctx context.Context, params *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error)
+	CreateServiceLinkedRole(ctx context.Context, params *iam.CreateServiceLinkedRoleInput, optFns ...func(*iam.Options)) (*iam.CreateServiceLinkedRoleOutput, error)
 }
 
 // account id and region are needed to form the bot arn
 type AwsClient struct {
-	Client    BotClient
-	AccountId string
-	Region    string
+	Client       BotClient
+	LambdaClient LambdaClient
+	IamClient    IamClient
+	// signs requests not sent through the sdk clients, e.g. to opensearch
+	Credentials aws.CredentialsProvider
+	AccountId   string
+	Region      string
+	// custom endpoints not handled by the sdk clients, e.g. the upload host
+	Endpoints Endpoints
+	// tags added to every resource, and tags never reported
+	DefaultTags map[string]string
+	IgnoreTags  IgnoreTags
+	// the settings the client was created with, see WithSource
+	config Config
 }
 
-func NewClient(region string, roleArn string) (*AwsClient, error) {
+// provider-level settings used to create the client
+type Config struct {
+	Region                    string
+	Profile                   string
+	SharedConfigFiles         []string
+	AssumeRole                *AssumeRole
+	AssumeRoleWithWebIdentity *AssumeRoleWithWebIdentity
+	Endpoints                 Endpoints
+	// skip the sts lookup of the caller identity (requires AccountId)
+	SkipCredentialsValidation bool
+	AccountId                 string
+	UseFIPSEndpoint           bool
+	// nil keeps the sdk default, zero disables retries
+	MaxRetries  *int
+	RetryMode   string
+	DefaultTags map[string]string
+	IgnoreTags  IgnoreTags
+}
 
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithRegion(region))
+// custom endpoints, e.g. for a local stand-in for aws. empty means the
+// default endpoint of the service
+type Endpoints struct {
+	LexModelsV2 string
+	Sts         string
+	Lambda      string
+	Iam         string
+	// replaces the scheme and host of the pre-signed archive upload url
+	Upload string
+}
 
-	if err != nil {
+var roleArnRegexp = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/.+$`)
+var sessionNameRegexp = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+var accountIdRegexp = regexp.MustCompile(`^\d{12}$`)
+
+const minRoleDuration = 15 * time.Minute
+const maxRoleDuration = 12 * time.Hour
+
+// Validate checks the settings before any call to aws is made
+func (config *Config) Validate() error {
+
+	if config.Region == "" {
+		return fmt.Errorf("region must be set")
+	}
+
+	for _, file := range config.SharedConfigFiles {
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("shared config file %s cannot be read: %s", file, err)
+		}
+	}
+
+	if config.AccountId != "" && !accountIdRegexp.MatchString(config.AccountId) {
+		return fmt.Errorf("account_id %q must be 12 digits", config.AccountId)
+	}
+
+	if config.SkipCredentialsValidation && config.AccountId == "" {
+		return fmt.Errorf("account_id must be set when skip_credentials_validation is true")
+	}
+
+	for name, endpoint := range config.Endpoints.byName() {
+		if endpoint == "" {
+			continue
+		}
+		if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("endpoint %s %q must be an absolute url, e.g. http://localhost:4566", name, endpoint)
+		}
+	}
+
+	if config.MaxRetries != nil && *config.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must not be negative")
+	}
+
+	if config.RetryMode != "" && config.RetryMode != RetryModeStandard && config.RetryMode != RetryModeAdaptive {
+		return fmt.Errorf("retry_mode %q must be %s or %s", config.RetryMode, RetryModeStandard, RetryModeAdaptive)
+	}
+
+	if role := config.AssumeRole; role != nil {
+
+		if err := validateRole(role.RoleArn, role.SessionName, role.Duration); err != nil {
+			return fmt.Errorf("invalid assume_role: %s", err)
+		}
+
+		if role.Policy != "" && !json.Valid([]byte(role.Policy)) {
+			return fmt.Errorf("invalid assume_role: policy is not valid json")
+		}
+
+		if role.ExternalId != "" && (len(role.ExternalId) < 2 || len(role.ExternalId) > 1224) {
+			return fmt.Errorf("invalid assume_role: external_id must be between 2 and 1224 characters")
+		}
+
+		if len(role.Tags) > 50 {
+			return fmt.Errorf("invalid assume_role: at most 50 session tags are allowed, got %d", len(role.Tags))
+		}
+	}
+
+	if role := config.AssumeRoleWithWebIdentity; role != nil {
+
+		if err := validateRole(role.RoleArn, role.SessionName, role.Duration); err != nil {
+			return fmt.Errorf("invalid assume_role_with_web_identity: %s", err)
+		}
+
+		if (role.WebIdentityToken == "") == (role.WebIdentityTokenFile == "") {
+			return fmt.Errorf("invalid assume_role_with_web_identity: exactly one of web_identity_token or web_identity_token_file must be set")
+		}
+
+		if role.WebIdentityTokenFile != "" {
+			if _, err := os.Stat(role.WebIdentityTokenFile); err != nil {
+				return fmt.Errorf("invalid assume_role_with_web_identity: web_identity_token_file cannot be read: %s", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e Endpoints) byName() map[string]string {
+	return map[string]string{
+		"lexmodelsv2": e.LexModelsV2,
+		"sts":         e.Sts,
+		"lambda":      e.Lambda,
+		"iam":         e.Iam,
+		"upload":      e.Upload,
+	}
+}
+
+func validateRole(roleArn string, sessionName string, duration time.Duration) error {
+
+	if !roleArnRegexp.MatchString(roleArn) {
+		return fmt.Errorf("role_arn %q is not a valid iam role arn", roleArn)
+	}
+
+	if sessionName != "" && !sessionNameRegexp.MatchString(sessionName) {
+		return fmt.Errorf("session_name %q must be 2-64 characters of letters, digits and +=,.@-", sessionName)
+	}
+
+	if duration != 0 && (duration < minRoleDuration || duration > maxRoleDuration) {
+		return fmt.Errorf("duration %s must be between %s and %s", duration, minRoleDuration, maxRoleDuration)
+	}
+
+	return nil
+}
+
+func NewClient(ctx context.Context, config Config) (*AwsClient, error) {
+
+	if err := config.Validate(); err != nil {
 		return nil, err
 	}
 
-	var awsClient AwsClient
+	loadOptions := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(config.Region),
+		awsconfig.WithRetryer(newRetryer(config.MaxRetries, config.RetryMode)),
+	}
 
-	if roleArn == "" {
+	if config.Profile != "" {
+		loadOptions = append(loadOptions, awsconfig.WithSharedConfigProfile(config.Profile))
+	}
 
-		// assume credentials available in the ~/.aws/credentials file
+	if len(config.SharedConfigFiles) > 0 {
+		loadOptions = append(loadOptions, awsconfig.WithSharedConfigFiles(config.SharedConfigFiles))
+	}
 
-		// determine account id from sts
-		stsClient := sts.NewFromConfig(cfg)
-		callerIdentityOutput, accountErr := stsClient.GetCallerIdentity(context.TODO(),
-			&sts.GetCallerIdentityInput{})
+	if config.UseFIPSEndpoint {
+		loadOptions = append(loadOptions, awsconfig.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, loadOptions...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := config.Endpoints
 
-		if accountErr != nil {
-			return nil, accountErr
+	stsOptions := func(o *sts.Options) {
+		if endpoints.Sts != "" {
+			o.EndpointResolver = sts.EndpointResolverFromURL(endpoints.Sts)
 		}
+	}
+
+	// exchange the web identity token first, so a role can be assumed from it
+	if config.AssumeRoleWithWebIdentity != nil {
 
-		client := lexmodelsv2.NewFromConfig(cfg)
-		awsClient = AwsClient{client, *callerIdentityOutput.Account, region}
+		log.Printf("[DEBUG] auth using web identity for role arn: %s\n", config.AssumeRoleWithWebIdentity.RoleArn)
 
-	} else {
+		cfg.Credentials = aws.NewCredentialsCache(
+			webIdentityProvider(sts.NewFromConfig(cfg, stsOptions), *config.AssumeRoleWithWebIdentity))
+	}
+
+	if config.AssumeRole != nil {
+
+		log.Printf("[DEBUG] auth using role arn: %s\n", config.AssumeRole.RoleArn)
+
+		// create temporary credentials from the iam role
+		cfg.Credentials = aws.NewCredentialsCache(
+			assumeRoleProvider(sts.NewFromConfig(cfg, stsOptions), *config.AssumeRole))
+	}
+
+	accountId := config.AccountId
 
-		log.Printf("[DEBUG] auth using role arn: %s\n", roleArn)
+	if !config.SkipCredentialsValidation {
 
-		// determine account id from sts
-		stsClient := sts.NewFromConfig(cfg)
-		callerIdentityOutput, accountErr := stsClient.GetCallerIdentity(context.TODO(),
+		// determine account id from sts, using the final credentials
+		stsClient := sts.NewFromConfig(cfg, stsOptions)
+		callerIdentityOutput, err := stsClient.GetCallerIdentity(ctx,
 			&sts.GetCallerIdentityInput{})
 
-		if accountErr != nil {
-			return nil, accountErr
+		if err != nil {
+			return nil, err
 		}
 
-		// create temporary credentials from the iam role
-		stsSvc := sts.NewFromConfig(cfg)
-		creds := stscreds.NewAssumeRoleProvider(stsSvc, roleArn)
-		cfg.Credentials = aws.NewCredentialsCache(creds)
-		client := lexmodelsv2.NewFromConfig(cfg)
+		if accountId == "" {
+			accountId = *callerIdentityOutput.Account
+		} else if accountId != *callerIdentityOutput.Account {
+			return nil, fmt.Errorf("account_id %s does not match the account of the credentials: %s", accountId, *callerIdentityOutput.Account)
+		}
+	}
 
-		awsClient = AwsClient{client, *callerIdentityOutput.Account, region}
+	awsClient := AwsClient{
+		Client: lexmodelsv2.NewFromConfig(cfg, func(o *lexmodelsv2.Options) {
+			if endpoints.LexModelsV2 != "" {
+				o.EndpointResolver = lexmodelsv2.EndpointResolverFromURL(endpoints.LexModelsV2)
+			}
+		}),
+		LambdaClient: lambda.NewFromConfig(cfg, func(o *lambda.Options) {
+			if endpoints.Lambda != "" {
+				o.EndpointResolver = lambda.EndpointResolverFromURL(endpoints.Lambda)
+			}
+		}),
+		IamClient: iam.NewFromConfig(cfg, func(o *iam.Options) {
+			if endpoints.Iam != "" {
+				o.EndpointResolver = iam.EndpointResolverFromURL(endpoints.Iam)
+			}
+		}),
+		Credentials: cfg.Credentials,
+		AccountId:   accountId,
+		Region:      config.Region,
+		Endpoints:   endpoints,
+		DefaultTags: config.DefaultTags,
+		IgnoreTags:  config.IgnoreTags,
+		config:      config,
 	}
 
 	return &awsClient, nil
@@ -2,13 +2,23 @@ package aws_client
 
 import (
 	"context"
+	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
 )
 
 // allow tests to pass in their own mock client
 func NewTestClient(client BotClient) (*AwsClient, error) {
-	c := AwsClient{client, "abcd", "us-west-2"}
+	c := AwsClient{
+		Client:       client,
+		LambdaClient: MockLambdaClient{},
+		IamClient:    MockIamClient{},
+		AccountId:    "abcd",
+		Region:       "us-west-2",
+	}
 	return &c, nil
 }
 
@@ -17,30 +27,244 @@ func NewTestClient(client BotClient) (*AwsClient, error) {
 type MockBotClient struct {
 	BotClient
 	// each test should specify the expected output and error
-	DescribeBotOutput         lexmodelsv2.DescribeBotOutput
-	ListBotAliasesOutput      lexmodelsv2.ListBotAliasesOutput
-	DescribeBotAliasOutput    lexmodelsv2.DescribeBotAliasOutput
-	DescribeBotVersionOutput  lexmodelsv2.DescribeBotVersionOutput
-	ListTagsForResourceOutput lexmodelsv2.ListTagsForResourceOutput
-	TagResourceOutput         lexmodelsv2.TagResourceOutput
-	err                       error
+	DescribeBotOutput                      lexmodelsv2.DescribeBotOutput
+	ListBotsOutput                         lexmodelsv2.ListBotsOutput
+	ListBotAliasesOutput                   lexmodelsv2.ListBotAliasesOutput
+	DescribeBotAliasOutput                 lexmodelsv2.DescribeBotAliasOutput
+	DescribeBotVersionOutput               lexmodelsv2.DescribeBotVersionOutput
+	ListBotVersionsOutput                  lexmodelsv2.ListBotVersionsOutput
+	ListTagsForResourceOutput              lexmodelsv2.ListTagsForResourceOutput
+	TagResourceOutput                      lexmodelsv2.TagResourceOutput
+	UntagResourceOutput                    lexmodelsv2.UntagResourceOutput
+	DescribeBotLocaleOutput                lexmodelsv2.DescribeBotLocaleOutput
+	ListBotLocalesOutput                   lexmodelsv2.ListBotLocalesOutput
+	CreateUploadUrlOutput                  lexmodelsv2.CreateUploadUrlOutput
+	StartImportOutput                      lexmodelsv2.StartImportOutput
+	DescribeImportOutput                   lexmodelsv2.DescribeImportOutput
+	BuildBotLocaleOutput                   lexmodelsv2.BuildBotLocaleOutput
+	CreateBotVersionOutput                 lexmodelsv2.CreateBotVersionOutput
+	UpdateBotAliasOutput                   lexmodelsv2.UpdateBotAliasOutput
+	CreateExportOutput                     lexmodelsv2.CreateExportOutput
+	DescribeExportOutput                   lexmodelsv2.DescribeExportOutput
+	DeleteExportOutput                     lexmodelsv2.DeleteExportOutput
+	UpdateBotLocaleOutput                  lexmodelsv2.UpdateBotLocaleOutput
+	CreateResourcePolicyOutput             lexmodelsv2.CreateResourcePolicyOutput
+	UpdateResourcePolicyOutput             lexmodelsv2.UpdateResourcePolicyOutput
+	DeleteResourcePolicyOutput             lexmodelsv2.DeleteResourcePolicyOutput
+	DescribeResourcePolicyOutput           lexmodelsv2.DescribeResourcePolicyOutput
+	ListBuiltInIntentsOutput               lexmodelsv2.ListBuiltInIntentsOutput
+	ListBuiltInSlotTypesOutput             lexmodelsv2.ListBuiltInSlotTypesOutput
+	ListAggregatedUtterancesOutput         lexmodelsv2.ListAggregatedUtterancesOutput
+	DeleteCustomVocabularyOutput           lexmodelsv2.DeleteCustomVocabularyOutput
+	DescribeCustomVocabularyMetadataOutput lexmodelsv2.DescribeCustomVocabularyMetadataOutput
+	err                                    error
+	// the inputs of the calls made, for tests that check what is sent
+	inputs *[]interface{}
 }
 
+func (m MockBotClient) record(params interface{}) {
+	if m.inputs != nil {
+		*m.inputs = append(*m.inputs, params)
+	}
+}
+
+func (m MockBotClient) DeleteCustomVocabulary(ctx context.Context, params *lexmodelsv2.DeleteCustomVocabularyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DeleteCustomVocabularyOutput, error) {
+	m.record(params)
+	return &m.DeleteCustomVocabularyOutput, m.err
+}
+func (m MockBotClient) DescribeCustomVocabularyMetadata(ctx context.Context, params *lexmodelsv2.DescribeCustomVocabularyMetadataInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeCustomVocabularyMetadataOutput, error) {
+	m.record(params)
+	return &m.DescribeCustomVocabularyMetadataOutput, m.err
+}
 func (m MockBotClient) ListBotAliases(ctx context.Context, params *lexmodelsv2.ListBotAliasesInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBotAliasesOutput, error) {
+	m.record(params)
 	return &m.ListBotAliasesOutput, m.err
 }
 func (m MockBotClient) DescribeBotAlias(ctx context.Context, params *lexmodelsv2.DescribeBotAliasInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeBotAliasOutput, error) {
+	m.record(params)
 	return &m.DescribeBotAliasOutput, m.err
 }
 func (m MockBotClient) DescribeBot(ctx context.Context, params *lexmodelsv2.DescribeBotInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeBotOutput, error) {
+	m.record(params)
 	return &m.DescribeBotOutput, m.err
 }
+func (m MockBotClient) ListBots(ctx context.Context, params *lexmodelsv2.ListBotsInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBotsOutput, error) {
+	m.record(params)
+	return &m.ListBotsOutput, m.err
+}
 func (m MockBotClient) DescribeBotVersion(ctx context.Context, params *lexmodelsv2.DescribeBotVersionInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeBotVersionOutput, error) {
+	m.record(params)
 	return &m.DescribeBotVersionOutput, m.err
 }
+func (m MockBotClient) ListBotVersions(ctx context.Context, params *lexmodelsv2.ListBotVersionsInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBotVersionsOutput, error) {
+	m.record(params)
+	return &m.ListBotVersionsOutput, m.err
+}
 func (m MockBotClient) ListTagsForResource(ctx context.Context, params *lexmodelsv2.ListTagsForResourceInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListTagsForResourceOutput, error) {
+	m.record(params)
 	return &m.ListTagsForResourceOutput, m.err
 }
 func (m MockBotClient) TagResource(ctx context.Context, params *lexmodelsv2.TagResourceInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.TagResourceOutput, error) {
+	m.record(params)
 	return &m.TagResourceOutput, m.err
 }
+func (m MockBotClient) UntagResource(ctx context.Context, params *lexmodelsv2.UntagResourceInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UntagResourceOutput, error) {
+	m.record(params)
+	return &m.UntagResourceOutput, m.err
+}
+func (m MockBotClient) ListBotLocales(ctx context.Context, params *lexmodelsv2.ListBotLocalesInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBotLocalesOutput, error) {
+	m.record(params)
+	return &m.ListBotLocalesOutput, m.err
+}
+func (m MockBotClient) CreateUploadUrl(ctx context.Context, params *lexmodelsv2.CreateUploadUrlInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateUploadUrlOutput, error) {
+	m.record(params)
+	return &m.CreateUploadUrlOutput, m.err
+}
+func (m MockBotClient) StartImport(ctx context.Context, params *lexmodelsv2.StartImportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.StartImportOutput, error) {
+	m.record(params)
+	return &m.StartImportOutput, m.err
+}
+func (m MockBotClient) DescribeImport(ctx context.Context, params *lexmodelsv2.DescribeImportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeImportOutput, error) {
+	m.record(params)
+	return &m.DescribeImportOutput, m.err
+}
+func (m MockBotClient) BuildBotLocale(ctx context.Context, params *lexmodelsv2.BuildBotLocaleInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.BuildBotLocaleOutput, error) {
+	m.record(params)
+	return &m.BuildBotLocaleOutput, m.err
+}
+func (m MockBotClient) CreateBotVersion(ctx context.Context, params *lexmodelsv2.CreateBotVersionInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateBotVersionOutput, error) {
+	m.record(params)
+	return &m.CreateBotVersionOutput, m.err
+}
+func (m MockBotClient) UpdateBotAlias(ctx context.Context, params *lexmodelsv2.UpdateBotAliasInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UpdateBotAliasOutput, error) {
+	m.record(params)
+	return &m.UpdateBotAliasOutput, m.err
+}
+func (m MockBotClient) CreateExport(ctx context.Context, params *lexmodelsv2.CreateExportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateExportOutput, error) {
+	m.record(params)
+	return &m.CreateExportOutput, m.err
+}
+func (m MockBotClient) DescribeExport(ctx context.Context, params *lexmodelsv2.DescribeExportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeExportOutput, error) {
+	m.record(params)
+	return &m.DescribeExportOutput, m.err
+}
+func (m MockBotClient) DeleteExport(ctx context.Context, params *lexmodelsv2.DeleteExportInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DeleteExportOutput, error) {
+	m.record(params)
+	return &m.DeleteExportOutput, m.err
+}
+func (m MockBotClient) ListBuiltInIntents(ctx context.Context, params *lexmodelsv2.ListBuiltInIntentsInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBuiltInIntentsOutput, error) {
+	m.record(params)
+	return &m.ListBuiltInIntentsOutput, m.err
+}
+func (m MockBotClient) ListBuiltInSlotTypes(ctx context.Context, params *lexmodelsv2.ListBuiltInSlotTypesInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListBuiltInSlotTypesOutput, error) {
+	m.record(params)
+	return &m.ListBuiltInSlotTypesOutput, m.err
+}
+func (m MockBotClient) ListAggregatedUtterances(ctx context.Context, params *lexmodelsv2.ListAggregatedUtterancesInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.ListAggregatedUtterancesOutput, error) {
+	m.record(params)
+	return &m.ListAggregatedUtterancesOutput, m.err
+}
+func (m MockBotClient) DescribeBotLocale(ctx context.Context, params *lexmodelsv2.DescribeBotLocaleInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeBotLocaleOutput, error) {
+	m.record(params)
+	return &m.DescribeBotLocaleOutput, m.err
+}
+func (m MockBotClient) UpdateBotLocale(ctx context.Context, params *lexmodelsv2.UpdateBotLocaleInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UpdateBotLocaleOutput, error) {
+	m.record(params)
+	return &m.UpdateBotLocaleOutput, m.err
+}
+func (m MockBotClient) CreateResourcePolicy(ctx context.Context, params *lexmodelsv2.CreateResourcePolicyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.CreateResourcePolicyOutput, error) {
+	m.record(params)
+	return &m.CreateResourcePolicyOutput, m.err
+}
+func (m MockBotClient) UpdateResourcePolicy(ctx context.Context, params *lexmodelsv2.UpdateResourcePolicyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.UpdateResourcePolicyOutput, error) {
+	m.record(params)
+	return &m.UpdateResourcePolicyOutput, m.err
+}
+func (m MockBotClient) DeleteResourcePolicy(ctx context.Context, params *lexmodelsv2.DeleteResourcePolicyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DeleteResourcePolicyOutput, error) {
+	m.record(params)
+	return &m.DeleteResourcePolicyOutput, m.err
+}
+func (m MockBotClient) DescribeResourcePolicy(ctx context.Context, params *lexmodelsv2.DescribeResourcePolicyInput, optFns ...func(*lexmodelsv2.Options)) (*lexmodelsv2.DescribeResourcePolicyOutput, error) {
+	m.record(params)
+	return &m.DescribeResourcePolicyOutput, m.err
+}
+
+// for use in unit tests that exercise the lambda permission
+type MockLambdaClient struct {
+	LambdaClient
+	AddPermissionOutput    lambda.AddPermissionOutput
+	RemovePermissionOutput lambda.RemovePermissionOutput
+	GetPolicyOutput        lambda.GetPolicyOutput
+	err                    error
+}
+
+func (m MockLambdaClient) AddPermission(ctx context.Context, params *lambda.AddPermissionInput, optFns ...func(*lambda.Options)) (*lambda.AddPermissionOutput, error) {
+	return &m.AddPermissionOutput, m.err
+}
+func (m MockLambdaClient) RemovePermission(ctx context.Context, params *lambda.RemovePermissionInput, optFns ...func(*lambda.Options)) (*lambda.RemovePermissionOutput, error) {
+	return &m.RemovePermissionOutput, m.err
+}
+func (m MockLambdaClient) GetPolicy(ctx context.Context, params *lambda.GetPolicyInput, optFns ...func(*lambda.Options)) (*lambda.GetPolicyOutput, error) {
+	return &m.GetPolicyOutput, m.err
+}
+
+// for use in unit tests that exercise the service-linked role
+type MockIamClient struct {
+	IamClient
+	GetRoleOutput                 iam.GetRoleOutput
+	CreateServiceLinkedRoleOutput iam.CreateServiceLinkedRoleOutput
+	err                           error
+}
+
+func (m MockIamClient) GetRole(ctx context.Context, params *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error) {
+	return &m.GetRoleOutput, m.err
+}
+func (m MockIamClient) CreateServiceLinkedRole(ctx context.Context, params *iam.CreateServiceLinkedRoleInput, optFns ...func(*iam.Options)) (*iam.CreateServiceLinkedRoleOutput, error) {
+	return &m.CreateServiceLinkedRoleOutput, m.err
+}
+
+func TestConfigValidate(t *testing.T) {
+
+	roleArn := "arn:aws:iam::123456789012:role/awslex-role"
+	maxRetries, noRetries, negativeRetries := 10, 0, -1
+
+	cases := []struct {
+		name    string
+		config  Config
+		isValid bool
+	}{
+		{"region only", Config{Region: "us-west-2"}, true},
+		{"missing region", Config{}, false},
+		{"missing shared config file", Config{Region: "us-west-2", SharedConfigFiles: []string{"/does/not/exist"}}, false},
+		{"assume role", Config{Region: "us-west-2", AssumeRole: &AssumeRole{
+			RoleArn: roleArn, SessionName: "pipeline", Duration: time.Hour, Policy: `{"Version":"2012-10-17"}`,
+			Tags: map[string]string{"unit": "shcva"}}}, true},
+		{"assume role with invalid arn", Config{Region: "us-west-2", AssumeRole: &AssumeRole{RoleArn: "awslex-role"}}, false},
+		{"assume role with short duration", Config{Region: "us-west-2", AssumeRole: &AssumeRole{RoleArn: roleArn, Duration: time.Minute}}, false},
+		{"assume role with invalid policy", Config{Region: "us-west-2", AssumeRole: &AssumeRole{RoleArn: roleArn, Policy: "{"}}, false},
+		{"assume role with invalid session name", Config{Region: "us-west-2", AssumeRole: &AssumeRole{RoleArn: roleArn, SessionName: "no spaces"}}, false},
+		{"web identity with token", Config{Region: "us-west-2", AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+			RoleArn: roleArn, WebIdentityToken: "token"}}, true},
+		{"web identity without token", Config{Region: "us-west-2", AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+			RoleArn: roleArn}}, false},
+		{"skip credentials validation", Config{Region: "us-west-2", SkipCredentialsValidation: true, AccountId: "000000000000",
+			Endpoints: Endpoints{LexModelsV2: "http://localhost:4566", Upload: "http://localhost:4566"}}, true},
+		{"skip credentials validation without account", Config{Region: "us-west-2", SkipCredentialsValidation: true}, false},
+		{"invalid account id", Config{Region: "us-west-2", AccountId: "abcd"}, false},
+		{"relative endpoint", Config{Region: "us-west-2", Endpoints: Endpoints{Sts: "localhost:4566"}}, false},
+		{"adaptive retries", Config{Region: "us-west-2", MaxRetries: &maxRetries, RetryMode: "adaptive"}, true},
+		{"no retries", Config{Region: "us-west-2", MaxRetries: &noRetries}, true},
+		{"negative retries", Config{Region: "us-west-2", MaxRetries: &negativeRetries}, false},
+		{"unknown retry mode", Config{Region: "us-west-2", RetryMode: "legacy"}, false},
+		{"web identity with token and file", Config{Region: "us-west-2", AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+			RoleArn: roleArn, WebIdentityToken: "token", WebIdentityTokenFile: "/tmp/token"}}, false},
+	}
+
+	for _, c := range cases {
+		err := c.config.Validate()
+
+		if (err == nil) != c.isValid {
+			t.Errorf("%s: expected valid=%t, got err: %v", c.name, c.isValid, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package aws_client
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+)
+
+const DefaultSessionName = "awslex-provider"
+
+// settings of a role assumed with sts AssumeRole
+type AssumeRole struct {
+	RoleArn     string
+	SessionName string
+	ExternalId  string
+	Duration    time.Duration
+	Policy      string
+	Tags        map[string]string
+}
+
+// settings of a role assumed with sts AssumeRoleWithWebIdentity, e.g. from
+// the oidc token of a ci runner
+type AssumeRoleWithWebIdentity struct {
+	RoleArn              string
+	SessionName          string
+	WebIdentityToken     string
+	WebIdentityTokenFile string
+	Duration             time.Duration
+}
+
+func assumeRoleProvider(client *sts.Client, role AssumeRole) aws.CredentialsProvider {
+	return stscreds.NewAssumeRoleProvider(client, role.RoleArn, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = sessionName(role.SessionName)
+		o.Duration = role.Duration
+
+		if role.ExternalId != "" {
+			o.ExternalID = &role.ExternalId
+		}
+		if role.Policy != "" {
+			o.Policy = &role.Policy
+		}
+		for key, value := range role.Tags {
+			o.Tags = append(o.Tags, ststypes.Tag{Key: getAddr(key), Value: getAddr(value)})
+		}
+	})
+}
+
+func webIdentityProvider(client *sts.Client, role AssumeRoleWithWebIdentity) aws.CredentialsProvider {
+
+	// the token file is read again on each refresh, since ci runners rotate it
+	var token stscreds.IdentityTokenRetriever = stscreds.IdentityTokenFile(role.WebIdentityTokenFile)
+	if role.WebIdentityToken != "" {
+		token = webIdentityToken(role.WebIdentityToken)
+	}
+
+	return stscreds.NewWebIdentityRoleProvider(client, role.RoleArn, token, func(o *stscreds.WebIdentityRoleOptions) {
+		o.RoleSessionName = sessionName(role.SessionName)
+		o.Duration = role.Duration
+	})
+}
+
+// a web identity token configured in place of a token file
+type webIdentityToken string
+
+func (t webIdentityToken) GetIdentityToken() ([]byte, error) {
+	return []byte(t), nil
+}
+
+func sessionName(name string) string {
+	if name == "" {
+		return DefaultSessionName
+	}
+	return name
+}
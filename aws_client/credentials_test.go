@@ -0,0 +1,103 @@
+package aws_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+// a stand-in for the sts role apis, keeping the form of the last request
+func newTestStsServer(t *testing.T) (*httptest.Server, *url.Values) {
+
+	var form url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+
+		action := form.Get("Action")
+		expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(`<` + action + `Response xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <` + action + `Result>
+    <Credentials>
+      <AccessKeyId>AKIDEXAMPLE</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>` + expiration + `</Expiration>
+    </Credentials>
+  </` + action + `Result>
+</` + action + `Response>`))
+	}))
+
+	return server, &form
+}
+
+func TestAssumeRoleCredentials(t *testing.T) {
+
+	server, form := newTestStsServer(t)
+	defer server.Close()
+
+	awsClient, err := NewClient(context.TODO(), Config{
+		Region:                    "us-west-2",
+		AccountId:                 "111111111111",
+		SkipCredentialsValidation: true,
+		Endpoints:                 Endpoints{Sts: server.URL},
+		AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+			RoleArn:          "arn:aws:iam::111111111111:role/ci",
+			WebIdentityToken: "oidc-token",
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	creds, err := awsClient.Credentials.Retrieve(context.TODO())
+
+	if err != nil || creds.AccessKeyID != "AKIDEXAMPLE" {
+		t.Fatalf("unexpected credentials: %+v, err: %v", creds, err)
+	}
+
+	if form.Get("Action") != "AssumeRoleWithWebIdentity" || form.Get("WebIdentityToken") != "oidc-token" ||
+		form.Get("RoleSessionName") != DefaultSessionName {
+		t.Errorf("unexpected web identity request: %v", *form)
+	}
+
+	// the role is assumed with the base credentials
+	for name, value := range map[string]string{"AWS_ACCESS_KEY_ID": "AKIDBASE", "AWS_SECRET_ACCESS_KEY": "secret"} {
+		if old, ok := os.LookupEnv(name); ok {
+			defer os.Setenv(name, old)
+		} else {
+			defer os.Unsetenv(name)
+		}
+		os.Setenv(name, value)
+	}
+
+	awsClient, _ = NewClient(context.TODO(), Config{
+		Region:                    "us-west-2",
+		AccountId:                 "111111111111",
+		SkipCredentialsValidation: true,
+		Endpoints:                 Endpoints{Sts: server.URL},
+		AssumeRole: &AssumeRole{
+			RoleArn:    "arn:aws:iam::111111111111:role/awslex-role",
+			ExternalId: "pipeline",
+			Duration:   2 * time.Hour,
+			Tags:       map[string]string{"unit": "shcva"},
+		},
+	})
+
+	if _, err = awsClient.Credentials.Retrieve(context.TODO()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if form.Get("Action") != "AssumeRole" || form.Get("ExternalId") != "pipeline" || form.Get("DurationSeconds") != "7200" ||
+		form.Get("Tags.member.1.Key") != "unit" || form.Get("Tags.member.1.Value") != "shcva" {
+		t.Errorf("unexpected assume role request: %v", *form)
+	}
+}
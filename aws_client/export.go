@@ -0,0 +1,300 @@
+package aws_client
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+// wait up to this many seconds for an export to complete
+const ExportWaitTimeoutSec = 300
+
+// what to export: a bot version, or a single locale of it
+type LexBotExport struct {
+	BotId      string
+	BotVersion string
+	// optional, exports every locale when empty
+	LocaleId string
+}
+
+// ExportBot exports a bot version in the import/export archive format, and
+// downloads the archive to outputPath
+func (c *AwsClient) ExportBot(ctx context.Context, export LexBotExport, outputPath string) error {
+
+	resourceSpecification := &types.ExportResourceSpecification{}
+
+	if export.LocaleId == "" {
+		resourceSpecification.BotExportSpecification = &types.BotExportSpecification{
+			BotId:      &export.BotId,
+			BotVersion: &export.BotVersion,
+		}
+	} else {
+		resourceSpecification.BotLocaleExportSpecification = &types.BotLocaleExportSpecification{
+			BotId:      &export.BotId,
+			BotVersion: &export.BotVersion,
+			LocaleId:   &export.LocaleId,
+		}
+	}
+
+	createExportOutput, err := c.Client.CreateExport(ctx, &lexmodelsv2.CreateExportInput{
+		FileFormat:            types.ImportExportFileFormatLexJson,
+		ResourceSpecification: resourceSpecification,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error exporting version %s of bot %s: %s", export.BotVersion, export.BotId, err)
+	}
+
+	exportId := aws.ToString(createExportOutput.ExportId)
+
+	downloadUrl, err := c.exportWait(ctx, exportId)
+
+	if err != nil {
+		return err
+	}
+
+	err = c.download(ctx, downloadUrl, outputPath)
+
+	if err != nil {
+		return err
+	}
+
+	// the archive is downloaded, the export is no longer needed
+	_, err = c.Client.DeleteExport(ctx, &lexmodelsv2.DeleteExportInput{
+		ExportId: &exportId,
+	})
+
+	if err != nil {
+		log.Printf("[WARN] unable to delete export %s: %s\n", exportId, err)
+	}
+
+	return nil
+}
+
+// wait for the export to complete, returning the url to download it from
+func (c *AwsClient) exportWait(ctx context.Context, exportId string) (string, error) {
+
+	expiredTimeSec := 0
+	sleepDurationSec := 10
+	for {
+		describeExportOutput, err := c.Client.DescribeExport(ctx, &lexmodelsv2.DescribeExportInput{
+			ExportId: &exportId,
+		})
+
+		if err != nil {
+			return "", fmt.Errorf("error describing export %s: %s", exportId, err)
+		}
+
+		switch describeExportOutput.ExportStatus {
+		case types.ExportStatusCompleted:
+			return aws.ToString(describeExportOutput.DownloadUrl), nil
+		case types.ExportStatusFailed:
+			return "", fmt.Errorf("export %s failed: %s", exportId, strings.Join(describeExportOutput.FailureReasons, ", "))
+		}
+
+		if expiredTimeSec >= ExportWaitTimeoutSec {
+			return "", fmt.Errorf("export %s did not complete within %d seconds", exportId, ExportWaitTimeoutSec)
+		}
+
+		log.Printf("[DEBUG] waiting for export to complete. Current status: %s\n", describeExportOutput.ExportStatus)
+
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return "", err
+		}
+		expiredTimeSec += sleepDurationSec
+	}
+}
+
+func (c *AwsClient) download(ctx context.Context, presignedUrl string, outputPath string) error {
+
+	downloadUrl, err := c.uploadUrl(presignedUrl)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", downloadUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download failed with response code: %d", rsp.StatusCode)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, rsp.Body)
+
+	return err
+}
+
+// ArchiveHash returns the base64-encoded sha256 of a file, i.e. the format of
+// the source_code_hash of a bot
+func ArchiveHash(path string) (string, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+// NormalizedArchiveHash returns the base64-encoded sha256 of the locales of an
+// exported archive, i.e. their intents, slots and slot types. The bot name,
+// settings and the identifiers lex assigns on import are left out, so the
+// same content exported from two bots has the same hash
+func NormalizedArchiveHash(path string) (string, error) {
+
+	files, root, err := readArchive(path)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := root + "/BotLocales/"
+	if root == "." {
+		prefix = "BotLocales/"
+	}
+
+	names := []string{}
+	for name := range files {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+
+	for _, name := range names {
+
+		content := files[name]
+
+		if filepath.Ext(name) == ".json" {
+			var document interface{}
+			if err = json.Unmarshal(content, &document); err != nil {
+				return "", fmt.Errorf("error reading %s: %s", name, err)
+			}
+			// map keys are marshalled in order
+			if content, err = json.Marshal(withoutIdentifiers(document)); err != nil {
+				return "", err
+			}
+		}
+
+		fmt.Fprintf(hash, "%s\x00%d\x00", strings.TrimPrefix(name, prefix), len(content))
+		hash.Write(content)
+	}
+
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+// drop the identifier and version lex assigns to each resource of a bot
+func withoutIdentifiers(document interface{}) interface{} {
+
+	switch value := document.(type) {
+	case map[string]interface{}:
+		delete(value, "identifier")
+		delete(value, "version")
+		for key, v := range value {
+			value[key] = withoutIdentifiers(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = withoutIdentifiers(v)
+		}
+	}
+
+	return document
+}
+
+// Unzip unpacks an archive into a directory
+func Unzip(archivePath string, dir string) error {
+
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, f := range reader.File {
+
+		path := filepath.Join(dir, f.Name)
+
+		// entries must stay inside the directory
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in archive: %s", f.Name)
+		}
+
+		if f.FileInfo().IsDir() {
+			if err = os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err = unzipFile(f, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unzipFile(f *zip.File, path string) error {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+
+	return err
+}
@@ -0,0 +1,87 @@
+package aws_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestExportBot(t *testing.T) {
+
+	// an archive in the import/export layout
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for _, name := range []string{"QnABot/Bot.json", "QnABot/BotLocales/en_US/BotLocale.json"} {
+		f, _ := writer.Create(name)
+		f.Write([]byte(`{}`))
+	}
+	writer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive.Bytes())
+	}))
+	defer server.Close()
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		CreateExportOutput: lexmodelsv2.CreateExportOutput{
+			ExportId: getAddr("EXPORTID"),
+		},
+		DescribeExportOutput: lexmodelsv2.DescribeExportOutput{
+			ExportStatus: types.ExportStatusCompleted,
+			DownloadUrl:  getAddr(server.URL + "/export.zip?X-Amz-Signature=abc"),
+		},
+	})
+
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "export", "bot.zip")
+
+	err := awsClient.ExportBot(context.TODO(), LexBotExport{BotId: "BOTID", BotVersion: "1"}, outputPath)
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	hash, err := ArchiveHash(outputPath)
+
+	if err != nil || hash == "" {
+		t.Errorf("expected a hash, got: %s, err: %v", hash, err)
+	}
+
+	if err = Unzip(outputPath, filepath.Join(dir, "sources")); err != nil {
+		t.Fatalf("expected no error unpacking, got: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "sources", "QnABot", "BotLocales", "en_US", "BotLocale.json"))
+
+	if err != nil || string(content) != `{}` {
+		t.Errorf("expected the archive to be unpacked, got: %s, err: %v", content, err)
+	}
+}
+
+func TestExportBotFailed(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		CreateExportOutput: lexmodelsv2.CreateExportOutput{
+			ExportId: getAddr("EXPORTID"),
+		},
+		DescribeExportOutput: lexmodelsv2.DescribeExportOutput{
+			ExportStatus:   types.ExportStatusFailed,
+			FailureReasons: []string{"bot version not found"},
+		},
+	})
+
+	err := awsClient.ExportBot(context.TODO(), LexBotExport{BotId: "BOTID", BotVersion: "9"}, filepath.Join(t.TempDir(), "bot.zip"))
+
+	if err == nil || !strings.Contains(err.Error(), "bot version not found") {
+		t.Errorf("expected the failure reason, got: %v", err)
+	}
+}
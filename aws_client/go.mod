@@ -3,10 +3,13 @@ module github.com/scg/va/aws_client
 go 1.16
 
 require (
-	github.com/aws/aws-sdk-go-v2 v1.11.2
-	github.com/aws/aws-sdk-go-v2/config v1.11.0
-	github.com/aws/aws-sdk-go-v2/credentials v1.6.4
-	github.com/aws/aws-sdk-go-v2/service/lexmodelsv2 v1.10.0
-	github.com/aws/aws-sdk-go-v2/service/sts v1.11.1
+	github.com/aws/aws-sdk-go-v2 v1.21.0
+	github.com/aws/aws-sdk-go-v2/config v1.18.37
+	github.com/aws/aws-sdk-go-v2/credentials v1.13.35
+	github.com/aws/aws-sdk-go-v2/service/iam v1.13.1
+	github.com/aws/aws-sdk-go-v2/service/lambda v1.14.1
+	github.com/aws/aws-sdk-go-v2/service/lexmodelsv2 v1.32.5
+	github.com/aws/aws-sdk-go-v2/service/sts v1.21.5
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.16.0
+	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,68 @@
+package aws_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+const lexServiceLinkedRolePrefix = "AWSServiceRoleForLexV2Bots_"
+
+// iam role names are limited to 64 characters
+const maxRoleNameLength = 64
+
+// seconds to wait for a new service-linked role to become usable by lex
+const serviceLinkedRoleWaitSec = 10
+
+// the custom suffix of the service-linked role used by a bot
+func serviceLinkedRoleSuffix(botName string) string {
+	maxSuffixLength := maxRoleNameLength - len(lexServiceLinkedRolePrefix)
+	if len(botName) > maxSuffixLength {
+		return botName[:maxSuffixLength]
+	}
+	return botName
+}
+
+// EnsureServiceLinkedRole sets the bot role to the lex service-linked role for
+// the bot, creating the role if it does not exist yet
+func (c *AwsClient) EnsureServiceLinkedRole(ctx context.Context, bot *LexBot) error {
+
+	suffix := serviceLinkedRoleSuffix(bot.Name)
+	roleName := lexServiceLinkedRolePrefix + suffix
+
+	getRoleOutput, err := c.IamClient.GetRole(ctx, &iam.GetRoleInput{
+		RoleName: &roleName,
+	})
+
+	if err == nil {
+		bot.IamRoleArn = *getRoleOutput.Role.Arn
+		return nil
+	}
+
+	var noSuchEntity *iamtypes.NoSuchEntityException
+	if !errors.As(err, &noSuchEntity) {
+		return fmt.Errorf("error getting service-linked role %s: %s", roleName, err)
+	}
+
+	log.Printf("[DEBUG] creating service-linked role: %s\n", roleName)
+
+	createServiceLinkedRoleOutput, err := c.IamClient.CreateServiceLinkedRole(ctx, &iam.CreateServiceLinkedRoleInput{
+		AWSServiceName: getAddr(lexServicePrincipal),
+		CustomSuffix:   &suffix,
+		Description:    getAddr(fmt.Sprintf("Service-linked role for lex bot %s", bot.Name)),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error creating service-linked role %s: %s", roleName, err)
+	}
+
+	bot.IamRoleArn = *createServiceLinkedRoleOutput.Role.Arn
+
+	// new roles are not immediately visible to lex
+	return sleepContext(ctx, time.Duration(serviceLinkedRoleWaitSec)*time.Second)
+}
@@ -0,0 +1,45 @@
+package aws_client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+func TestEnsureServiceLinkedRoleExisting(t *testing.T) {
+
+	roleArn := "arn:aws:iam::abcd:role/aws-service-role/lexv2.amazonaws.com/AWSServiceRoleForLexV2Bots_TerraBot"
+
+	awsClient, _ := NewTestClient(MockBotClient{})
+	awsClient.IamClient = MockIamClient{
+		GetRoleOutput: iam.GetRoleOutput{
+			Role: &iamtypes.Role{
+				Arn: &roleArn,
+			},
+		},
+	}
+
+	bot := LexBot{Name: "TerraBot"}
+
+	err := awsClient.EnsureServiceLinkedRole(context.TODO(), &bot)
+
+	if err != nil || bot.IamRoleArn != roleArn {
+		t.Errorf("expected role %s, got: %s, err: %v", roleArn, bot.IamRoleArn, err)
+	}
+}
+
+func TestServiceLinkedRoleSuffix(t *testing.T) {
+
+	suffix := serviceLinkedRoleSuffix(strings.Repeat("a", 100))
+
+	if len(lexServiceLinkedRolePrefix+suffix) != maxRoleNameLength {
+		t.Errorf("expected role name to be truncated to %d characters, got suffix: %s", maxRoleNameLength, suffix)
+	}
+
+	if serviceLinkedRoleSuffix("TerraBot") != "TerraBot" {
+		t.Error("expected short bot names to be used as is")
+	}
+}
@@ -0,0 +1,190 @@
+package aws_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+const lexServicePrincipal = "lexv2.amazonaws.com"
+const lambdaInvokeAction = "lambda:InvokeFunction"
+
+// statement id of the permission managed for a bot alias
+func lambdaStatementId(bot *LexBot) string {
+	return fmt.Sprintf("awslex-%s-%s", bot.Id, bot.AliasId)
+}
+
+// AddLambdaPermission allows the bot alias to invoke its fulfillment lambda
+func (c *AwsClient) AddLambdaPermission(ctx context.Context, bot *LexBot) error {
+
+	if bot.LambdaArn == "" || bot.AliasId == "" {
+		return nil
+	}
+
+	log.Printf("[DEBUG] adding invoke permission for alias %s to lambda: %s\n", bot.AliasId, bot.LambdaArn)
+
+	_, err := c.LambdaClient.AddPermission(ctx, &lambda.AddPermissionInput{
+		FunctionName: &bot.LambdaArn,
+		StatementId:  getAddr(lambdaStatementId(bot)),
+		Action:       getAddr(lambdaInvokeAction),
+		Principal:    getAddr(lexServicePrincipal),
+		SourceArn:    getAddr(c.AliasArn(bot.Id, bot.AliasId)),
+	})
+
+	// the statement id is already in use, i.e. the permission exists
+	var conflict *lambdatypes.ResourceConflictException
+	if errors.As(err, &conflict) {
+		return nil
+	}
+
+	return err
+}
+
+// RemoveLambdaPermission removes the statement added by AddLambdaPermission
+func (c *AwsClient) RemoveLambdaPermission(ctx context.Context, bot *LexBot) error {
+
+	if bot.LambdaArn == "" || bot.AliasId == "" {
+		return nil
+	}
+
+	log.Printf("[DEBUG] removing invoke permission for alias %s from lambda: %s\n", bot.AliasId, bot.LambdaArn)
+
+	_, err := c.LambdaClient.RemovePermission(ctx, &lambda.RemovePermissionInput{
+		FunctionName: &bot.LambdaArn,
+		StatementId:  getAddr(lambdaStatementId(bot)),
+	})
+
+	// the statement (or the function) is already gone
+	var notFound *lambdatypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil
+	}
+
+	return err
+}
+
+// HasLambdaPermission reports whether any statement in the lambda policy allows
+// lex to invoke the lambda from the bot alias, whoever added it
+func (c *AwsClient) HasLambdaPermission(ctx context.Context, bot *LexBot) (bool, error) {
+
+	getPolicyOutput, err := c.LambdaClient.GetPolicy(ctx, &lambda.GetPolicyInput{
+		FunctionName: &bot.LambdaArn,
+	})
+
+	// functions without any permissions have no policy
+	var notFound *lambdatypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if getPolicyOutput.Policy == nil {
+		return false, nil
+	}
+
+	var policy lambdaPolicy
+	if err = json.Unmarshal([]byte(*getPolicyOutput.Policy), &policy); err != nil {
+		return false, fmt.Errorf("error parsing policy of lambda %s: %s", bot.LambdaArn, err)
+	}
+
+	aliasArn := c.AliasArn(bot.Id, bot.AliasId)
+
+	for _, statement := range policy.Statement {
+		if statement.allowsLexInvoke(aliasArn) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+type lambdaPolicy struct {
+	Statement []lambdaPolicyStatement
+}
+
+type lambdaPolicyStatement struct {
+	Effect    string
+	Principal interface{}
+	Action    interface{}
+	Condition map[string]map[string]interface{}
+}
+
+func (s lambdaPolicyStatement) allowsLexInvoke(aliasArn string) bool {
+
+	if s.Effect != "Allow" {
+		return false
+	}
+
+	if !containsValue(s.Action, lambdaInvokeAction) && !containsValue(s.Action, "lambda:*") {
+		return false
+	}
+
+	// principals are either a plain string or a map keyed by principal type
+	principal, ok := s.Principal.(map[string]interface{})
+	if !ok || !containsValue(principal["Service"], lexServicePrincipal) {
+		return false
+	}
+
+	// a permission without a source arn allows any bot to invoke the lambda
+	sourceArnFound := false
+	for operator, condition := range s.Condition {
+		for key, value := range condition {
+			if !strings.EqualFold(key, "AWS:SourceArn") {
+				continue
+			}
+			sourceArnFound = true
+			if operator == "ArnLike" && matchesArnPattern(value, aliasArn) {
+				return true
+			}
+			if operator == "ArnEquals" && containsValue(value, aliasArn) {
+				return true
+			}
+		}
+	}
+
+	return !sourceArnFound
+}
+
+// policy values are either a single string or a list of strings
+func containsValue(value interface{}, expected string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == expected || v == "*"
+	case []interface{}:
+		for _, item := range v {
+			if containsValue(item, expected) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ArnLike patterns support * and ? wildcards
+func matchesArnPattern(value interface{}, arn string) bool {
+	switch v := value.(type) {
+	case string:
+		pattern := regexp.QuoteMeta(v)
+		pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+		pattern = strings.ReplaceAll(pattern, `\?`, ".")
+		match, err := regexp.MatchString("^"+pattern+"$", arn)
+		return err == nil && match
+	case []interface{}:
+		for _, item := range v {
+			if matchesArnPattern(item, arn) {
+				return true
+			}
+		}
+	}
+	return false
+}
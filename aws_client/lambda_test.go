@@ -0,0 +1,49 @@
+package aws_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+func TestHasLambdaPermission(t *testing.T) {
+
+	bot := LexBot{
+		Id:        "some-bot",
+		AliasId:   "some-alias",
+		LambdaArn: "arn:aws:lambda:us-west-2:abcd:function:fulfillment",
+	}
+
+	cases := []struct {
+		policy   string
+		expected bool
+	}{
+		// permission scoped to every alias of the bot
+		{`{"Statement":[{"Effect":"Allow","Principal":{"Service":"lexv2.amazonaws.com"},"Action":"lambda:InvokeFunction",
+			"Condition":{"ArnLike":{"AWS:SourceArn":"arn:aws:lex:us-west-2:abcd:bot-alias/some-bot/*"}}}]}`, true},
+		// permission scoped to the alias
+		{`{"Statement":[{"Effect":"Allow","Principal":{"Service":"lexv2.amazonaws.com"},"Action":["lambda:InvokeFunction"],
+			"Condition":{"ArnEquals":{"AWS:SourceArn":"arn:aws:lex:us-west-2:abcd:bot-alias/some-bot/some-alias"}}}]}`, true},
+		// permission scoped to another bot
+		{`{"Statement":[{"Effect":"Allow","Principal":{"Service":"lexv2.amazonaws.com"},"Action":"lambda:InvokeFunction",
+			"Condition":{"ArnLike":{"AWS:SourceArn":"arn:aws:lex:us-west-2:abcd:bot-alias/other-bot/*"}}}]}`, false},
+		// permission for another service
+		{`{"Statement":[{"Effect":"Allow","Principal":{"Service":"apigateway.amazonaws.com"},"Action":"lambda:InvokeFunction"}]}`, false},
+	}
+
+	for _, c := range cases {
+		awsClient, _ := NewTestClient(MockBotClient{})
+		awsClient.LambdaClient = MockLambdaClient{
+			GetPolicyOutput: lambda.GetPolicyOutput{
+				Policy: getAddr(c.policy),
+			},
+		}
+
+		found, err := awsClient.HasLambdaPermission(context.TODO(), &bot)
+
+		if err != nil || found != c.expected {
+			t.Errorf("expected %t, got %t, err: %v, policy: %s", c.expected, found, err, c.policy)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+// overrides applied to a bot locale after the archive is imported
+type LexBotLocale struct {
+	LocaleId string
+	// zero means keep the threshold defined in the archive
+	NluConfidenceThreshold float64
+	VoiceId                string
+	VoiceEngine            string
+	// phrases imported as the custom vocabulary of the locale, or a tab
+	// separated file of them
+	CustomVocabulary     []LexVocabularyItem
+	CustomVocabularyFile string
+}
+
+// the locale configures a custom vocabulary
+func (locale LexBotLocale) hasVocabulary() bool {
+	return len(locale.CustomVocabulary) > 0 || locale.CustomVocabularyFile != ""
+}
+
+const DefaultLocale = "en_US"
+
+const VoiceEngineStandard = "standard"
+const VoiceEngineNeural = "neural"
+
+type pollyVoice struct {
+	locale   string
+	standard bool
+	neural   bool
+}
+
+// polly voices available to lex, keyed by voice id
+var pollyVoices = map[string]pollyVoice{
+	"Ivy":       {"en_US", true, true},
+	"Joanna":    {"en_US", true, true},
+	"Kendra":    {"en_US", true, true},
+	"Kimberly":  {"en_US", true, true},
+	"Salli":     {"en_US", true, true},
+	"Joey":      {"en_US", true, true},
+	"Justin":    {"en_US", true, true},
+	"Kevin":     {"en_US", false, true},
+	"Matthew":   {"en_US", true, true},
+	"Amy":       {"en_GB", true, true},
+	"Emma":      {"en_GB", true, true},
+	"Brian":     {"en_GB", true, true},
+	"Nicole":    {"en_AU", true, false},
+	"Olivia":    {"en_AU", false, true},
+	"Russell":   {"en_AU", true, false},
+	"Lupe":      {"es_US", true, true},
+	"Penelope":  {"es_US", true, false},
+	"Miguel":    {"es_US", true, false},
+	"Conchita":  {"es_ES", true, false},
+	"Lucia":     {"es_ES", true, true},
+	"Enrique":   {"es_ES", true, false},
+	"Mia":       {"es_419", true, false},
+	"Chantal":   {"fr_CA", true, false},
+	"Gabrielle": {"fr_CA", false, true},
+	"Celine":    {"fr_FR", true, false},
+	"Lea":       {"fr_FR", true, true},
+	"Mathieu":   {"fr_FR", true, false},
+	"Marlene":   {"de_DE", true, false},
+	"Vicki":     {"de_DE", true, true},
+	"Hans":      {"de_DE", true, false},
+	"Carla":     {"it_IT", true, false},
+	"Bianca":    {"it_IT", true, true},
+	"Giorgio":   {"it_IT", true, false},
+	"Mizuki":    {"ja_JP", true, false},
+	"Takumi":    {"ja_JP", true, true},
+}
+
+// ValidateVoice checks that a polly voice (and optionally engine) can be used
+// with the given locale
+func ValidateVoice(localeId string, voiceId string, engine string) error {
+
+	voice, ok := pollyVoices[voiceId]
+
+	if !ok {
+		return fmt.Errorf("unknown voice %s", voiceId)
+	}
+
+	if voice.locale != localeId {
+		return fmt.Errorf("voice %s is not available in locale %s (voice locale is %s)", voiceId, localeId, voice.locale)
+	}
+
+	switch engine {
+	case "":
+	case VoiceEngineStandard:
+		if !voice.standard {
+			return fmt.Errorf("voice %s does not support the %s engine", voiceId, engine)
+		}
+	case VoiceEngineNeural:
+		if !voice.neural {
+			return fmt.Errorf("voice %s does not support the %s engine", voiceId, engine)
+		}
+	default:
+		return fmt.Errorf("unknown voice engine %s", engine)
+	}
+
+	return nil
+}
+
+// locales that need to be built, versioned and served by the alias: en_US,
+// any overridden locale and any locale with qna pairs
+func (bot *LexBot) localeIds() []string {
+
+	localeIds := []string{DefaultLocale}
+	seen := map[string]bool{DefaultLocale: true}
+
+	add := func(localeId string) {
+		if !seen[localeId] {
+			seen[localeId] = true
+			localeIds = append(localeIds, localeId)
+		}
+	}
+
+	for _, locale := range bot.Locales {
+		add(locale.LocaleId)
+	}
+
+	for _, qna := range bot.Qna {
+		add(qna.LocaleId)
+	}
+
+	return localeIds
+}
+
+// the fulfillment lambda is called for every locale of the bot
+func (bot *LexBot) aliasLocaleSettings() map[string]types.BotAliasLocaleSettings {
+
+	settings := make(map[string]types.BotAliasLocaleSettings)
+
+	for _, localeId := range bot.localeIds() {
+		settings[localeId] = types.BotAliasLocaleSettings{
+			CodeHookSpecification: &types.CodeHookSpecification{
+				LambdaCodeHook: &types.LambdaCodeHook{
+					LambdaARN:                &bot.LambdaArn,
+					CodeHookInterfaceVersion: getAddr("1.0"),
+				},
+			},
+			Enabled: true,
+		}
+	}
+
+	return settings
+}
+
+// apply the locale overrides to the draft version of the bot
+func (c *AwsClient) updateLocales(ctx context.Context, bot *LexBot) error {
+
+	for _, locale := range bot.Locales {
+
+		describeBotLocaleOutput, err := c.Client.DescribeBotLocale(ctx, &lexmodelsv2.DescribeBotLocaleInput{
+			BotId:      &bot.Id,
+			BotVersion: getAddr(DraftVersion),
+			LocaleId:   getAddr(locale.LocaleId),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error describing bot locale %s: %s", locale.LocaleId, err)
+		}
+
+		// start from the settings defined in the archive
+		threshold := describeBotLocaleOutput.NluIntentConfidenceThreshold
+		voiceSettings := describeBotLocaleOutput.VoiceSettings
+
+		if locale.NluConfidenceThreshold != 0 {
+			threshold = &locale.NluConfidenceThreshold
+		}
+
+		if locale.VoiceId != "" {
+			voiceSettings = &types.VoiceSettings{
+				VoiceId: getAddr(locale.VoiceId),
+			}
+		}
+
+		if locale.VoiceEngine != "" {
+			if voiceSettings == nil {
+				return fmt.Errorf("voice engine set for locale %s but no voice is configured", locale.LocaleId)
+			}
+			voiceSettings = &types.VoiceSettings{
+				VoiceId: voiceSettings.VoiceId,
+				Engine:  types.VoiceEngine(locale.VoiceEngine),
+			}
+		}
+
+		log.Printf("[DEBUG] updating bot locale %s\n", locale.LocaleId)
+
+		_, err = c.Client.UpdateBotLocale(ctx, &lexmodelsv2.UpdateBotLocaleInput{
+			BotId:                        &bot.Id,
+			BotVersion:                   getAddr(DraftVersion),
+			LocaleId:                     getAddr(locale.LocaleId),
+			Description:                  describeBotLocaleOutput.Description,
+			NluIntentConfidenceThreshold: threshold,
+			VoiceSettings:                voiceSettings,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error updating bot locale %s: %s", locale.LocaleId, err)
+		}
+	}
+
+	return nil
+}
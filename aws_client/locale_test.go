@@ -0,0 +1,94 @@
+package aws_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestValidateVoice(t *testing.T) {
+
+	cases := []struct {
+		locale  string
+		voice   string
+		engine  string
+		isValid bool
+	}{
+		{"en_US", "Joanna", "", true},
+		{"en_US", "Joanna", "neural", true},
+		{"en_US", "Kevin", "standard", false},
+		{"es_US", "Lupe", "neural", true},
+		{"es_US", "Joanna", "", false},
+		{"fr_CA", "Chantal", "neural", false},
+		{"fr_CA", "Gabrielle", "neural", true},
+		{"en_US", "Nobody", "", false},
+		{"en_US", "Joanna", "turbo", false},
+	}
+
+	for _, c := range cases {
+		err := ValidateVoice(c.locale, c.voice, c.engine)
+
+		if (err == nil) != c.isValid {
+			t.Errorf("voice %s, locale %s, engine %s: expected valid=%t, got err: %v",
+				c.voice, c.locale, c.engine, c.isValid, err)
+		}
+	}
+}
+
+func TestUpdateLocales(t *testing.T) {
+
+	var inputs []interface{}
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		DescribeBotLocaleOutput: lexmodelsv2.DescribeBotLocaleOutput{
+			NluIntentConfidenceThreshold: getFloatAddr(0.4),
+			VoiceSettings: &types.VoiceSettings{
+				VoiceId: getAddr("Joanna"),
+			},
+		},
+		err:    nil,
+		inputs: &inputs,
+	})
+
+	bot := LexBot{
+		Id: "some-id",
+		Locales: []LexBotLocale{
+			{LocaleId: "en_US", NluConfidenceThreshold: 0.7},
+			{LocaleId: "es_US", VoiceId: "Lupe", VoiceEngine: "neural"},
+		},
+	}
+
+	err := awsClient.updateLocales(context.TODO(), &bot)
+
+	if err != nil {
+		t.Log("error should be nil", err)
+		t.Fail()
+	}
+
+	voiceSettings := map[string]*types.VoiceSettings{}
+	for _, input := range inputs {
+		if update, ok := input.(*lexmodelsv2.UpdateBotLocaleInput); ok {
+			voiceSettings[*update.LocaleId] = update.VoiceSettings
+		}
+	}
+
+	if settings := voiceSettings["es_US"]; settings == nil || *settings.VoiceId != "Lupe" || settings.Engine != types.VoiceEngineNeural {
+		t.Errorf("expected the neural Lupe voice for es_US, got: %+v", settings)
+	}
+
+	// the voice of the archive is kept
+	if settings := voiceSettings["en_US"]; settings == nil || *settings.VoiceId != "Joanna" || settings.Engine != "" {
+		t.Errorf("expected the Joanna voice for en_US, got: %+v", settings)
+	}
+
+	localeIds := bot.localeIds()
+	if len(localeIds) != 2 || localeIds[0] != "en_US" || localeIds[1] != "es_US" {
+		t.Errorf("unexpected locale ids: %v", localeIds)
+	}
+}
+
+func getFloatAddr(f float64) *float64 {
+	return &f
+}
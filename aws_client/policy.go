@@ -0,0 +1,101 @@
+package aws_client
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+// resource-based policy attached to a bot or bot alias
+type LexResourcePolicy struct {
+	ResourceArn string
+	Policy      string
+	RevisionId  string
+}
+
+func (c *AwsClient) GetResourcePolicy(ctx context.Context, resourceArn string) (LexResourcePolicy, error) {
+
+	describeResourcePolicyOutput, err := c.Client.DescribeResourcePolicy(ctx,
+		&lexmodelsv2.DescribeResourcePolicyInput{
+			ResourceArn: &resourceArn,
+		})
+
+	if err != nil {
+		return LexResourcePolicy{}, err
+	}
+
+	policy := LexResourcePolicy{
+		ResourceArn: resourceArn,
+	}
+
+	if describeResourcePolicyOutput.Policy != nil {
+		policy.Policy = *describeResourcePolicyOutput.Policy
+	}
+	if describeResourcePolicyOutput.RevisionId != nil {
+		policy.RevisionId = *describeResourcePolicyOutput.RevisionId
+	}
+
+	return policy, nil
+}
+
+func (c *AwsClient) CreateResourcePolicy(ctx context.Context, policy *LexResourcePolicy) error {
+
+	log.Printf("[DEBUG] creating resource policy for: %s\n", policy.ResourceArn)
+
+	createResourcePolicyOutput, err := c.Client.CreateResourcePolicy(ctx,
+		&lexmodelsv2.CreateResourcePolicyInput{
+			ResourceArn: &policy.ResourceArn,
+			Policy:      &policy.Policy,
+		})
+
+	if err != nil {
+		return err
+	}
+
+	policy.RevisionId = *createResourcePolicyOutput.RevisionId
+
+	return nil
+}
+
+func (c *AwsClient) UpdateResourcePolicy(ctx context.Context, policy *LexResourcePolicy) error {
+
+	log.Printf("[DEBUG] updating resource policy for: %s, revision: %s\n", policy.ResourceArn, policy.RevisionId)
+
+	// the expected revision guards against overwriting changes made outside terraform
+	updateResourcePolicyOutput, err := c.Client.UpdateResourcePolicy(ctx,
+		&lexmodelsv2.UpdateResourcePolicyInput{
+			ResourceArn:        &policy.ResourceArn,
+			Policy:             &policy.Policy,
+			ExpectedRevisionId: &policy.RevisionId,
+		})
+
+	if err != nil {
+		return err
+	}
+
+	policy.RevisionId = *updateResourcePolicyOutput.RevisionId
+
+	return nil
+}
+
+func (c *AwsClient) DeleteResourcePolicy(ctx context.Context, policy LexResourcePolicy) error {
+
+	log.Printf("[DEBUG] deleting resource policy for: %s, revision: %s\n", policy.ResourceArn, policy.RevisionId)
+
+	_, err := c.Client.DeleteResourcePolicy(ctx,
+		&lexmodelsv2.DeleteResourcePolicyInput{
+			ResourceArn:        &policy.ResourceArn,
+			ExpectedRevisionId: &policy.RevisionId,
+		})
+
+	return err
+}
+
+// true if the error indicates the requested lex resource does not exist
+func IsNotFoundError(err error) bool {
+	var notFound *types.ResourceNotFoundException
+	return errors.As(err, &notFound)
+}
@@ -0,0 +1,62 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestResourcePolicyRevisions(t *testing.T) {
+
+	aliasArn := "arn:aws:lex:us-west-2:abcd:bot-alias/some-bot/some-alias"
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		CreateResourcePolicyOutput: lexmodelsv2.CreateResourcePolicyOutput{
+			ResourceArn: &aliasArn,
+			RevisionId:  getAddr("1"),
+		},
+		UpdateResourcePolicyOutput: lexmodelsv2.UpdateResourcePolicyOutput{
+			ResourceArn: &aliasArn,
+			RevisionId:  getAddr("2"),
+		},
+		DescribeResourcePolicyOutput: lexmodelsv2.DescribeResourcePolicyOutput{
+			ResourceArn: &aliasArn,
+			Policy:      getAddr("{}"),
+			RevisionId:  getAddr("2"),
+		},
+		err: nil,
+	})
+
+	policy := LexResourcePolicy{
+		ResourceArn: aliasArn,
+		Policy:      "{}",
+	}
+
+	if err := awsClient.CreateResourcePolicy(context.TODO(), &policy); err != nil || policy.RevisionId != "1" {
+		t.Errorf("expected revision 1, got: %s, err: %v", policy.RevisionId, err)
+	}
+
+	if err := awsClient.UpdateResourcePolicy(context.TODO(), &policy); err != nil || policy.RevisionId != "2" {
+		t.Errorf("expected revision 2, got: %s, err: %v", policy.RevisionId, err)
+	}
+
+	readPolicy, err := awsClient.GetResourcePolicy(context.TODO(), aliasArn)
+
+	if err != nil || readPolicy.RevisionId != "2" || readPolicy.Policy != "{}" {
+		t.Errorf("unexpected policy: %+v, err: %v", readPolicy, err)
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+
+	if !IsNotFoundError(fmt.Errorf("wrapped: %w", &types.ResourceNotFoundException{})) {
+		t.Error("expected not found error to be detected")
+	}
+
+	if IsNotFoundError(fmt.Errorf("some other error")) {
+		t.Error("expected other errors not to be detected as not found")
+	}
+}
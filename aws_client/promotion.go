@@ -0,0 +1,251 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+)
+
+// where a promoted bot version comes from, when not the provider account
+// and region
+type PromotionSource struct {
+	// defaults to the provider region
+	Region string
+	// role to assume in the source account
+	RoleArn string
+	// account id of the role, only needed with skip_credentials_validation
+	AccountId string
+}
+
+// a bot version promoted from a source bot into a target bot
+type LexBotPromotion struct {
+	SourceBotId   string
+	SourceVersion string
+	// optional, the promotion fails unless the source version was built
+	// from the archive with this hash
+	ExpectedSourceCodeHash string
+
+	TargetBotId string
+	Alias       string
+	LambdaArn   string
+
+	// set by PromoteBot. ArchiveHash is the normalized hash of the source
+	// export, which the export of the target version must match
+	Version        string
+	AliasId        string
+	SourceCodeHash string
+	ArchiveHash    string
+}
+
+// WithSource returns a client for the source of a promotion: the provider
+// settings with the source region and role
+func (c *AwsClient) WithSource(ctx context.Context, source PromotionSource) (*AwsClient, error) {
+
+	config := c.config
+
+	if source.Region != "" {
+		config.Region = source.Region
+	}
+
+	// the source role replaces the provider role, and is assumed with the
+	// provider base credentials (profile or web identity)
+	if source.RoleArn != "" {
+		config.AssumeRole = &AssumeRole{RoleArn: source.RoleArn}
+		config.AccountId = source.AccountId
+	}
+
+	return NewClient(ctx, config)
+}
+
+// PromoteBot exports a version of the source bot and imports it into the
+// target bot, then builds it, creates a version and points the alias at it
+func (c *AwsClient) PromoteBot(ctx context.Context, source *AwsClient, promotion *LexBotPromotion) error {
+
+	// the source code hash is stored in the description of a version
+	describeBotVersionOutput, err := source.Client.DescribeBotVersion(ctx, &lexmodelsv2.DescribeBotVersionInput{
+		BotId:      &promotion.SourceBotId,
+		BotVersion: &promotion.SourceVersion,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error describing version %s of source bot %s: %s", promotion.SourceVersion, promotion.SourceBotId, err)
+	}
+
+	promotion.SourceCodeHash = aws.ToString(describeBotVersionOutput.Description)
+
+	if promotion.ExpectedSourceCodeHash != "" && promotion.SourceCodeHash != promotion.ExpectedSourceCodeHash {
+		return fmt.Errorf("version %s of source bot %s was built from source code hash %s, not %s",
+			promotion.SourceVersion, promotion.SourceBotId, promotion.SourceCodeHash, promotion.ExpectedSourceCodeHash)
+	}
+
+	dir, err := ioutil.TempDir("", "awslex-promotion")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	archivePath := filepath.Join(dir, "bot.zip")
+
+	err = source.ExportBot(ctx, LexBotExport{
+		BotId:      promotion.SourceBotId,
+		BotVersion: promotion.SourceVersion,
+	}, archivePath)
+
+	if err != nil {
+		return err
+	}
+
+	promotion.ArchiveHash, err = NormalizedArchiveHash(archivePath)
+	if err != nil {
+		return err
+	}
+
+	// the import needs the name and role of the target bot
+	describeBotOutput, err := c.Client.DescribeBot(ctx, &lexmodelsv2.DescribeBotInput{
+		BotId: &promotion.TargetBotId,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error describing target bot %s: %s", promotion.TargetBotId, err)
+	}
+
+	bot := LexBot{
+		Id:          promotion.TargetBotId,
+		Name:        aws.ToString(describeBotOutput.BotName),
+		IamRoleArn:  aws.ToString(describeBotOutput.RoleArn),
+		Alias:       promotion.Alias,
+		LambdaArn:   promotion.LambdaArn,
+		ArchivePath: archivePath,
+		// the target version records the same source code hash as the source
+		SourceCodeHash: promotion.SourceCodeHash,
+	}
+
+	log.Printf("[DEBUG] promoting version %s of bot %s into bot %s\n", promotion.SourceVersion, promotion.SourceBotId, bot.Id)
+
+	uploadId, err := c.upload(ctx, archivePath)
+	if err != nil {
+		return err
+	}
+
+	err = c.importBot(ctx, uploadId, bot)
+	if err != nil {
+		return err
+	}
+
+	// build and version every locale of the promoted version
+	bot.Locales, err = c.draftLocales(ctx, bot.Id)
+	if err != nil {
+		return err
+	}
+
+	err = c.buildBot(ctx, &bot)
+	if err != nil {
+		return err
+	}
+
+	err = c.createVersion(ctx, &bot)
+	if err != nil {
+		return err
+	}
+
+	promotion.Version = bot.Version
+
+	// make sure the version created has the content of the source version
+	// before the alias is pointed at it
+	err = c.VerifyPromotion(ctx, promotion)
+	if err != nil {
+		return err
+	}
+
+	err = c.createOrUpdateAlias(ctx, &bot)
+	if err != nil {
+		return err
+	}
+
+	promotion.AliasId = bot.AliasId
+
+	return nil
+}
+
+// VerifyPromotion exports the target version and checks that its normalized
+// archive hash is that of the source export
+func (c *AwsClient) VerifyPromotion(ctx context.Context, promotion *LexBotPromotion) error {
+
+	dir, err := ioutil.TempDir("", "awslex-promotion")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	archivePath := filepath.Join(dir, "target.zip")
+
+	err = c.ExportBot(ctx, LexBotExport{
+		BotId:      promotion.TargetBotId,
+		BotVersion: promotion.Version,
+	}, archivePath)
+
+	if err != nil {
+		return err
+	}
+
+	hash, err := NormalizedArchiveHash(archivePath)
+	if err != nil {
+		return err
+	}
+
+	if hash != promotion.ArchiveHash {
+		return fmt.Errorf("version %s of bot %s does not match version %s of source bot %s: archive hash %s, not %s",
+			promotion.Version, promotion.TargetBotId, promotion.SourceVersion, promotion.SourceBotId, hash, promotion.ArchiveHash)
+	}
+
+	return nil
+}
+
+// ReadPromotion sets the source code hash recorded with the target version
+func (c *AwsClient) ReadPromotion(ctx context.Context, promotion *LexBotPromotion) error {
+
+	describeBotVersionOutput, err := c.Client.DescribeBotVersion(ctx, &lexmodelsv2.DescribeBotVersionInput{
+		BotId:      &promotion.TargetBotId,
+		BotVersion: &promotion.Version,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	promotion.SourceCodeHash = aws.ToString(describeBotVersionOutput.Description)
+
+	return nil
+}
+
+// the locales of the draft version of a bot
+func (c *AwsClient) draftLocales(ctx context.Context, botId string) ([]LexBotLocale, error) {
+
+	locales := []LexBotLocale{}
+
+	paginator := lexmodelsv2.NewListBotLocalesPaginator(c.Client, &lexmodelsv2.ListBotLocalesInput{
+		BotId:      &botId,
+		BotVersion: getAddr(DraftVersion),
+	})
+
+	for paginator.HasMorePages() {
+
+		listBotLocalesOutput, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("error listing locales of bot %s: %s", botId, err)
+		}
+
+		for _, summary := range listBotLocalesOutput.BotLocaleSummaries {
+			locales = append(locales, LexBotLocale{LocaleId: aws.ToString(summary.LocaleId)})
+		}
+	}
+
+	return locales, nil
+}
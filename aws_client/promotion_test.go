@@ -0,0 +1,183 @@
+package aws_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+// an exported bot with a single intent
+func testExport(botName string, identifier string, utterance string) []byte {
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"Manifest.json":       `{"metaData":{"fileFormat":"LexJson"}}`,
+		botName + "/Bot.json": `{"name":"` + botName + `","identifier":"` + identifier + `"}`,
+		botName + "/BotLocales/en_US/Intents/Greeting/Intent.json": `{"name":"Greeting","identifier":"` + identifier +
+			`","sampleUtterances":[{"utterance":"` + utterance + `"}]}`,
+	} {
+		f, _ := writer.Create(name)
+		f.Write([]byte(content))
+	}
+	writer.Close()
+
+	return archive.Bytes()
+}
+
+func TestPromoteBot(t *testing.T) {
+
+	sourceExport := testExport("DevBot", "SOURCEINTENT", "hello")
+	// the same intent, imported into another bot
+	targetExport := testExport("ProdBot", "TARGETINTENT", "hello")
+
+	// serves the export downloads, and accepts the import upload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/source.zip":
+			w.Write(sourceExport)
+		case r.Method == "GET" && r.URL.Path == "/target.zip":
+			w.Write(targetExport)
+		}
+	}))
+	defer server.Close()
+
+	source, _ := NewTestClient(MockBotClient{
+		DescribeBotVersionOutput: lexmodelsv2.DescribeBotVersionOutput{
+			Description: getAddr("some-hash"),
+		},
+		CreateExportOutput: lexmodelsv2.CreateExportOutput{
+			ExportId: getAddr("EXPORTID"),
+		},
+		DescribeExportOutput: lexmodelsv2.DescribeExportOutput{
+			ExportStatus: types.ExportStatusCompleted,
+			DownloadUrl:  getAddr(server.URL + "/source.zip"),
+		},
+	})
+
+	var inputs []interface{}
+
+	target, _ := NewTestClient(MockBotClient{
+		DescribeBotOutput: lexmodelsv2.DescribeBotOutput{
+			BotName: getAddr("bot-test"),
+			RoleArn: getAddr("some-arn"),
+		},
+		CreateUploadUrlOutput: lexmodelsv2.CreateUploadUrlOutput{
+			ImportId:  getAddr("IMPORTID"),
+			UploadUrl: getAddr(server.URL + "/import.zip"),
+		},
+		DescribeImportOutput: lexmodelsv2.DescribeImportOutput{
+			ImportStatus: types.ImportStatusCompleted,
+		},
+		ListBotLocalesOutput: lexmodelsv2.ListBotLocalesOutput{
+			BotLocaleSummaries: []types.BotLocaleSummary{
+				{LocaleId: getAddr("en_US")},
+				{LocaleId: getAddr("es_US")},
+			},
+		},
+		DescribeBotLocaleOutput: lexmodelsv2.DescribeBotLocaleOutput{
+			BotLocaleStatus: types.BotLocaleStatusBuilt,
+		},
+		CreateBotVersionOutput: lexmodelsv2.CreateBotVersionOutput{
+			BotVersion: getAddr("4"),
+		},
+		DescribeBotVersionOutput: lexmodelsv2.DescribeBotVersionOutput{
+			BotStatus:   types.BotStatusAvailable,
+			Description: getAddr("some-hash"),
+		},
+		CreateExportOutput: lexmodelsv2.CreateExportOutput{
+			ExportId: getAddr("TARGETEXPORTID"),
+		},
+		DescribeExportOutput: lexmodelsv2.DescribeExportOutput{
+			ExportStatus: types.ExportStatusCompleted,
+			DownloadUrl:  getAddr(server.URL + "/target.zip"),
+		},
+		ListBotAliasesOutput: lexmodelsv2.ListBotAliasesOutput{
+			BotAliasSummaries: []types.BotAliasSummary{
+				{BotAliasId: getAddr("ALIASID"), BotAliasName: getAddr("latest")},
+			},
+		},
+		DescribeBotAliasOutput: lexmodelsv2.DescribeBotAliasOutput{
+			BotAliasStatus: types.BotAliasStatusAvailable,
+		},
+		inputs: &inputs,
+	})
+
+	promotion := LexBotPromotion{
+		SourceBotId:            "SOURCEID",
+		SourceVersion:          "7",
+		ExpectedSourceCodeHash: "some-hash",
+		TargetBotId:            "TARGETID",
+		Alias:                  "latest",
+	}
+
+	err := target.PromoteBot(context.TODO(), source, &promotion)
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if promotion.Version != "4" || promotion.AliasId != "ALIASID" || promotion.SourceCodeHash != "some-hash" || promotion.ArchiveHash == "" {
+		t.Errorf("unexpected promotion: %+v", promotion)
+	}
+
+	// a target version with other content fails the promotion, before the
+	// alias is pointed at it
+	targetExport = testExport("ProdBot", "TARGETINTENT", "goodbye")
+	inputs = nil
+
+	err = target.PromoteBot(context.TODO(), source, &promotion)
+
+	if err == nil || !strings.Contains(err.Error(), "does not match version 7 of source bot SOURCEID") {
+		t.Errorf("expected the content mismatch to be an error, got: %v", err)
+	}
+
+	for _, input := range inputs {
+		if _, ok := input.(*lexmodelsv2.UpdateBotAliasInput); ok {
+			t.Errorf("expected the alias to be left as it is")
+		}
+	}
+
+	// a source version built from other sources is not promoted
+	promotion.ExpectedSourceCodeHash = "other-hash"
+
+	err = target.PromoteBot(context.TODO(), source, &promotion)
+
+	if err == nil || !strings.Contains(err.Error(), "other-hash") {
+		t.Errorf("expected the hash mismatch to be an error, got: %v", err)
+	}
+}
+
+func TestWithSource(t *testing.T) {
+
+	awsClient, err := NewClient(context.TODO(), Config{
+		Region:                    "us-west-2",
+		AccountId:                 "111111111111",
+		SkipCredentialsValidation: true,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	source, err := awsClient.WithSource(context.TODO(), PromotionSource{
+		Region:    "us-east-1",
+		RoleArn:   "arn:aws:iam::222222222222:role/awslex-export",
+		AccountId: "222222222222",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if source.Region != "us-east-1" || source.AccountId != "222222222222" {
+		t.Errorf("unexpected source client: %+v", source)
+	}
+}
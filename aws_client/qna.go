@@ -0,0 +1,452 @@
+package aws_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// a question and answer pair of a bot locale. The questions become values of
+// the QnaSlotType, the answer is looked up by the fulfillment lambda
+type LexQna struct {
+	Id        string
+	LocaleId  string
+	Questions []string
+	Answer    string
+}
+
+// names of the resources generated for the question and answer pairs
+const QnaIntentName = "QnaIntent"
+const QnaSlotName = "qnaslot"
+const QnaSlotTypeName = "QnaSlotType"
+
+// locales lex can build a bot in
+var lexLocales = map[string]bool{
+	"ar_AE": true, "ca_ES": true, "da_DK": true, "de_AT": true, "de_CH": true,
+	"de_DE": true, "en_AU": true, "en_GB": true, "en_IE": true, "en_IN": true,
+	"en_NZ": true, "en_US": true, "en_ZA": true, "es_419": true, "es_ES": true,
+	"es_US": true, "fi_FI": true, "fr_CA": true, "fr_FR": true, "hi_IN": true,
+	"it_IT": true, "ja_JP": true, "ko_KR": true, "nl_NL": true, "no_NO": true,
+	"pl_PL": true, "pt_BR": true, "pt_PT": true, "sv_SE": true, "yue_HK": true,
+	"zh_CN": true,
+}
+
+// the longest slot type value lex accepts
+const maxQuestionLength = 140
+
+// ValidateQna checks for missing ids, questions and answers, for ids or
+// questions repeated within a locale, and for locales and questions lex cannot
+// build
+func ValidateQna(pairs []LexQna) error {
+
+	ids := make(map[string]bool)
+	questions := make(map[string]string)
+
+	for _, qna := range pairs {
+
+		if qna.Id == "" {
+			return fmt.Errorf("qna ids must not be empty")
+		}
+
+		key := qna.LocaleId + "/" + qna.Id
+		if ids[key] {
+			return fmt.Errorf("qna %s is listed more than once for locale %s", qna.Id, qna.LocaleId)
+		}
+		ids[key] = true
+
+		if !lexLocales[qna.LocaleId] {
+			return fmt.Errorf("qna %s has locale %s, which lex does not support", qna.Id, qna.LocaleId)
+		}
+
+		if qna.Answer == "" {
+			return fmt.Errorf("qna %s of locale %s has no answer", qna.Id, qna.LocaleId)
+		}
+
+		if len(qna.Questions) == 0 {
+			return fmt.Errorf("qna %s of locale %s has no questions", qna.Id, qna.LocaleId)
+		}
+
+		for _, question := range qna.Questions {
+
+			if strings.TrimSpace(question) == "" {
+				return fmt.Errorf("qna %s of locale %s has an empty question", qna.Id, qna.LocaleId)
+			}
+
+			if utf8.RuneCountInString(question) > maxQuestionLength {
+				return fmt.Errorf("question %q of qna %s is longer than %d characters", question, qna.Id, maxQuestionLength)
+			}
+
+			// slot type values must be unique, regardless of case
+			key := qna.LocaleId + "/" + strings.ToLower(question)
+			if other, ok := questions[key]; ok {
+				return fmt.Errorf("question %q of locale %s is listed by both qna %s and %s", question, qna.LocaleId, other, qna.Id)
+			}
+			questions[key] = qna.Id
+		}
+	}
+
+	return nil
+}
+
+// answer and questions of a qna in one locale
+type qnaAnswer struct {
+	Answer    string   `json:"answer"`
+	Questions []string `json:"questions"`
+}
+
+// QnaAnswers returns the answers as json, keyed by qna id and then locale, for
+// the fulfillment lambda to look up the answer to a question
+func QnaAnswers(pairs []LexQna) (string, error) {
+
+	answers := make(map[string]map[string]qnaAnswer)
+
+	for _, qna := range pairs {
+		if answers[qna.Id] == nil {
+			answers[qna.Id] = make(map[string]qnaAnswer)
+		}
+		answers[qna.Id][qna.LocaleId] = qnaAnswer{qna.Answer, qna.Questions}
+	}
+
+	// maps are marshalled with sorted keys, so the json is stable
+	b, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// WriteQnaAnswers writes the answers json to a file
+func WriteQnaAnswers(path string, pairs []LexQna) error {
+
+	answers, err := QnaAnswers(pairs)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, []byte(answers), 0644)
+}
+
+// QnaArchiveHash returns the source code hash of the archive generated for the
+// question and answer pairs of the bot
+func QnaArchiveHash(bot LexBot) (string, error) {
+
+	b, err := QnaArchive(bot)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(b)
+
+	return base64.StdEncoding.EncodeToString(hash[:]), nil
+}
+
+// QnaArchive returns an import archive with a QnaIntent, its qnaslot and the
+// QnaSlotType for each locale with question and answer pairs. The intents,
+// slot types and locales of the archive at bot.ArchivePath are kept, except
+// the qna resources of those locales, which are replaced. Without an archive
+// path, the bot and its locales are generated too. The archive only depends
+// on its content, so its hash only changes with the content
+func QnaArchive(bot LexBot) ([]byte, error) {
+
+	files, _, err := qnaArchiveFiles(bot)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeArchive(files)
+}
+
+// ValidateQnaLocales checks that every locale the bot builds is in the
+// archive generated for the qna pairs. A locale is missing when it has a
+// locale block but neither pairs nor a locale in the archive at
+// bot.ArchivePath, or, without an archive path, when it has no pairs
+func ValidateQnaLocales(bot LexBot) error {
+
+	files, root, err := qnaArchiveFiles(bot)
+	if err != nil {
+		return err
+	}
+
+	for _, localeId := range bot.localeIds() {
+		if _, ok := files[path.Join(root, "BotLocales", localeId, "BotLocale.json")]; !ok {
+			return fmt.Errorf("locale %s cannot be built: it has no qna pairs and is not in the archive", localeId)
+		}
+	}
+
+	return nil
+}
+
+// the files of the archive generated for the qna pairs, and the directory
+// containing Bot.json
+func qnaArchiveFiles(bot LexBot) (map[string][]byte, string, error) {
+
+	if err := ValidateQna(bot.Qna); err != nil {
+		return nil, "", err
+	}
+
+	files := make(map[string][]byte)
+	root := bot.Name
+
+	if bot.ArchivePath != "" {
+
+		var err error
+		files, root, err = readArchive(bot.ArchivePath)
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading archive %s: %s", bot.ArchivePath, err)
+		}
+	} else {
+
+		files["Manifest.json"] = mustMarshal(map[string]interface{}{
+			"metaData": map[string]interface{}{
+				"fileFormat":    "LexJson",
+				"resourceType":  "BOT",
+				"schemaVersion": "1",
+			},
+		})
+
+		files[path.Join(root, "Bot.json")] = mustMarshal(map[string]interface{}{
+			"name":        bot.Name,
+			"version":     nil,
+			"description": bot.Description,
+			"identifier":  qnaIdentifier(bot.Name),
+			"dataPrivacy": map[string]interface{}{
+				"childDirected": false,
+			},
+			"idleSessionTTLInSeconds": ttl,
+		})
+	}
+
+	// questions of each locale, in the order they are configured
+	localeIds := []string{}
+	questions := make(map[string][]string)
+
+	for _, qna := range bot.Qna {
+		if _, ok := questions[qna.LocaleId]; !ok {
+			localeIds = append(localeIds, qna.LocaleId)
+		}
+		questions[qna.LocaleId] = append(questions[qna.LocaleId], qna.Questions...)
+	}
+
+	for _, localeId := range localeIds {
+
+		localeDir := path.Join(root, "BotLocales", localeId)
+		intentDir := path.Join(localeDir, "Intents", QnaIntentName)
+		slotTypeDir := path.Join(localeDir, "SlotTypes", QnaSlotTypeName)
+
+		// replace the qna resources of the archive
+		for name := range files {
+			if strings.HasPrefix(name, intentDir+"/") || strings.HasPrefix(name, slotTypeDir+"/") {
+				delete(files, name)
+			}
+		}
+
+		if _, ok := files[path.Join(localeDir, "BotLocale.json")]; !ok {
+			files[path.Join(localeDir, "BotLocale.json")] = qnaBotLocale(localeId)
+			files[path.Join(localeDir, "Intents", "FallbackIntent", "Intent.json")] = qnaFallbackIntent(localeId)
+		}
+
+		files[path.Join(intentDir, "Intent.json")] = qnaIntent(localeId)
+		files[path.Join(intentDir, "Slots", QnaSlotName, "Slot.json")] = qnaSlot(localeId)
+		files[path.Join(slotTypeDir, "SlotType.json")] = qnaSlotType(localeId, questions[localeId])
+	}
+
+	return files, root, nil
+}
+
+// read the files of an archive, along with the directory containing Bot.json
+func readArchive(archivePath string) (map[string][]byte, string, error) {
+
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer reader.Close()
+
+	files := make(map[string][]byte)
+	root := ""
+
+	for _, f := range reader.File {
+
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, "", err
+		}
+
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, "", err
+		}
+
+		files[f.Name] = b
+
+		if path.Base(f.Name) == "Bot.json" {
+			root = path.Dir(f.Name)
+		}
+	}
+
+	if root == "" {
+		return nil, "", fmt.Errorf("no Bot.json found")
+	}
+
+	return files, root, nil
+}
+
+// zip the files in name order, without timestamps
+func writeArchive(files map[string][]byte) ([]byte, error) {
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+
+	for _, name := range names {
+
+		file, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = file.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return archive.Bytes(), nil
+}
+
+// a 10 character identifier, stable for the same names. lex assigns its own
+// identifiers on import, but the format requires one
+func qnaIdentifier(names ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(names, "/")))
+	return base32.StdEncoding.EncodeToString(hash[:])[:10]
+}
+
+func mustMarshal(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func qnaBotLocale(localeId string) []byte {
+	return mustMarshal(map[string]interface{}{
+		"name":                   localeId,
+		"identifier":             localeId,
+		"version":                nil,
+		"description":            nil,
+		"voiceSettings":          nil,
+		"nluConfidenceThreshold": 0.4,
+	})
+}
+
+func qnaFallbackIntent(localeId string) []byte {
+	return mustMarshal(map[string]interface{}{
+		"name":                  "FallbackIntent",
+		"identifier":            qnaIdentifier(localeId, "FallbackIntent"),
+		"description":           nil,
+		"parentIntentSignature": "AMAZON.FallbackIntent",
+		"sampleUtterances":      nil,
+		"fulfillmentCodeHook": map[string]interface{}{
+			"enabled": true,
+		},
+		"slotPriorities": []interface{}{},
+	})
+}
+
+// an intent matching any question, which lex passes to the lambda in the slot
+func qnaIntent(localeId string) []byte {
+	return mustMarshal(map[string]interface{}{
+		"name":                  QnaIntentName,
+		"identifier":            qnaIdentifier(localeId, QnaIntentName),
+		"description":           nil,
+		"parentIntentSignature": nil,
+		"sampleUtterances": []interface{}{
+			map[string]interface{}{"utterance": "{" + QnaSlotName + "}"},
+		},
+		"fulfillmentCodeHook": map[string]interface{}{
+			"enabled": true,
+		},
+		"slotPriorities": []interface{}{
+			map[string]interface{}{"priority": 1, "slotName": QnaSlotName},
+		},
+	})
+}
+
+func qnaSlot(localeId string) []byte {
+	return mustMarshal(map[string]interface{}{
+		"name":         QnaSlotName,
+		"identifier":   qnaIdentifier(localeId, QnaSlotName),
+		"description":  nil,
+		"slotTypeName": QnaSlotTypeName,
+		"valueElicitationSetting": map[string]interface{}{
+			"promptSpecification": map[string]interface{}{
+				"allowInterrupt": true,
+				"messageGroupsList": []interface{}{
+					map[string]interface{}{
+						"message": map[string]interface{}{
+							"plainTextMessage": map[string]interface{}{
+								"value": "What is the question?",
+							},
+						},
+					},
+				},
+				"maxRetries": 4,
+			},
+			"slotConstraint": "Optional",
+		},
+	})
+}
+
+// a slot type whose values are the questions, resolved to the question asked
+func qnaSlotType(localeId string, questions []string) []byte {
+
+	values := []interface{}{}
+	for _, question := range questions {
+		values = append(values, map[string]interface{}{
+			"sampleValue": map[string]interface{}{"value": question},
+			"synonyms":    nil,
+		})
+	}
+
+	return mustMarshal(map[string]interface{}{
+		"name":                    QnaSlotTypeName,
+		"identifier":              qnaIdentifier(localeId, QnaSlotTypeName),
+		"description":             nil,
+		"slotTypeValues":          values,
+		"parentSlotTypeSignature": nil,
+		"valueSelectionSetting": map[string]interface{}{
+			"resolutionStrategy": "ORIGINAL_VALUE",
+			"regexFilter":        nil,
+		},
+	})
+}
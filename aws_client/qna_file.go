@@ -0,0 +1,233 @@
+package aws_client
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// a row of a qna file, one question of a qna in one locale. The answer may
+// be left empty on all but one row of the qna
+type qnaRow struct {
+	Id       string `yaml:"id"`
+	Locale   string `yaml:"locale"`
+	Question string `yaml:"question"`
+	Answer   string `yaml:"answer"`
+	// where the row is, for errors
+	position string
+}
+
+// ReadQnaFile reads question and answer pairs from a csv file with an `id`,
+// `locale`, `question` and `answer` header line, or from a yaml list of rows
+// with the same keys. Rows of the same id and locale make up one qna. The
+// locale defaults to en_US
+func ReadQnaFile(path string) ([]LexQna, error) {
+
+	var rows []qnaRow
+	var err error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err = readQnaCsv(path)
+	case ".yaml", ".yml":
+		rows, err = readQnaYaml(path)
+	default:
+		return nil, fmt.Errorf("%s: qna files must be .csv, .yaml or .yml", path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := groupQnaRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s", path, err)
+	}
+
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("%s has no questions", path)
+	}
+
+	return pairs, nil
+}
+
+func readQnaCsv(path string) ([]qnaRow, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	// spreadsheets leave trailing columns out
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%s: error reading header: %s", path, err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		// a byte order mark is common in csv files saved by spreadsheets
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	for _, name := range []string{"id", "question", "answer"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%s: header has no %s column", path, name)
+		}
+	}
+
+	column := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := []qnaRow{}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+
+		rows = append(rows, qnaRow{
+			Id:       column(record, "id"),
+			Locale:   column(record, "locale"),
+			Question: column(record, "question"),
+			Answer:   column(record, "answer"),
+			// the row number in a spreadsheet, after the header row
+			position: fmt.Sprintf("row %d", len(rows)+2),
+		})
+	}
+
+	return rows, nil
+}
+
+func readQnaYaml(path string) ([]qnaRow, error) {
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []qnaRow{}
+
+	if err = yaml.UnmarshalStrict(b, &rows); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	for i := range rows {
+		rows[i].Id = strings.TrimSpace(rows[i].Id)
+		rows[i].Locale = strings.TrimSpace(rows[i].Locale)
+		rows[i].Question = strings.TrimSpace(rows[i].Question)
+		rows[i].Answer = strings.TrimSpace(rows[i].Answer)
+		rows[i].position = fmt.Sprintf("row %d", i+1)
+	}
+
+	return rows, nil
+}
+
+// one qna per id and locale, in the order they first appear
+func groupQnaRows(rows []qnaRow) ([]LexQna, error) {
+
+	pairs := []LexQna{}
+	index := make(map[string]int)
+
+	for _, row := range rows {
+
+		// blank lines
+		if row.Id == "" && row.Question == "" && row.Answer == "" {
+			continue
+		}
+
+		if row.Id == "" {
+			return nil, fmt.Errorf("%s: id must not be empty", row.position)
+		}
+
+		if row.Locale == "" {
+			row.Locale = DefaultLocale
+		}
+
+		key := row.Locale + "/" + row.Id
+
+		i, ok := index[key]
+		if !ok {
+			i = len(pairs)
+			index[key] = i
+			pairs = append(pairs, LexQna{Id: row.Id, LocaleId: row.Locale, Questions: []string{}})
+		}
+
+		if row.Question != "" {
+			pairs[i].Questions = append(pairs[i].Questions, row.Question)
+		}
+
+		if row.Answer == "" {
+			continue
+		}
+
+		if pairs[i].Answer != "" && pairs[i].Answer != row.Answer {
+			return nil, fmt.Errorf("%s: qna %s of locale %s has a different answer than before", row.position, row.Id, row.Locale)
+		}
+		pairs[i].Answer = row.Answer
+	}
+
+	return pairs, nil
+}
+
+// MissingQnaTranslations lists, for each qna id (in order), the locales of
+// the other pairs it has no pair for, e.g. "gas-leak: fr_CA"
+func MissingQnaTranslations(pairs []LexQna) []string {
+
+	ids := []string{}
+	locales := make(map[string]bool)
+	translated := make(map[string]map[string]bool)
+
+	for _, qna := range pairs {
+		if translated[qna.Id] == nil {
+			translated[qna.Id] = make(map[string]bool)
+			ids = append(ids, qna.Id)
+		}
+		translated[qna.Id][qna.LocaleId] = true
+		locales[qna.LocaleId] = true
+	}
+
+	allLocales := []string{}
+	for locale := range locales {
+		allLocales = append(allLocales, locale)
+	}
+	sort.Strings(allLocales)
+
+	missing := []string{}
+
+	for _, id := range ids {
+
+		missingLocales := []string{}
+		for _, locale := range allLocales {
+			if !translated[id][locale] {
+				missingLocales = append(missingLocales, locale)
+			}
+		}
+
+		if len(missingLocales) > 0 {
+			missing = append(missing, fmt.Sprintf("%s: %s", id, strings.Join(missingLocales, ", ")))
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,102 @@
+package aws_client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeQnaFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	os.WriteFile(path, []byte(content), 0644)
+	return path
+}
+
+func TestReadQnaFileCsv(t *testing.T) {
+
+	path := writeQnaFile(t, "qna.csv", "\ufeffid,locale,question,answer,notes\n"+
+		"gas-leak,en_US,I smell gas in my house,Call 911,reviewed\n"+
+		"gas-leak,en_US,emergency gas leak\n"+
+		",,,\n"+
+		"gas-leak,es_US,Huelo gas en mi casa,Llame al 911\n"+
+		"password-reset,,\"I forgot my password, help\",Reset it in My Account\n")
+
+	pairs, err := ReadQnaFile(path)
+
+	if err != nil || len(pairs) != 3 {
+		t.Fatalf("expected 3 pairs, got: %v, err: %v", pairs, err)
+	}
+
+	if pairs[0].Id != "gas-leak" || pairs[0].LocaleId != "en_US" || len(pairs[0].Questions) != 2 || pairs[0].Answer != "Call 911" {
+		t.Errorf("unexpected pair: %+v", pairs[0])
+	}
+
+	if pairs[2].LocaleId != DefaultLocale || pairs[2].Questions[0] != "I forgot my password, help" {
+		t.Errorf("unexpected pair: %+v", pairs[2])
+	}
+}
+
+func TestReadQnaFileYaml(t *testing.T) {
+
+	path := writeQnaFile(t, "qna.yaml", `
+- id: gas-leak
+  locale: en_US
+  question: I smell gas in my house
+  answer: Call 911
+- id: gas-leak
+  locale: fr_CA
+  question: Je sens le gaz dans ma maison
+  answer: Appelez le 911
+`)
+
+	pairs, err := ReadQnaFile(path)
+
+	if err != nil || len(pairs) != 2 || pairs[1].Answer != "Appelez le 911" {
+		t.Fatalf("unexpected pairs: %v, err: %v", pairs, err)
+	}
+}
+
+func TestReadQnaFileErrors(t *testing.T) {
+
+	cases := []struct {
+		name    string
+		content string
+		message string
+	}{
+		{"qna.txt", "", "must be .csv"},
+		{"qna.csv", "id,question\n", "no answer column"},
+		{"qna.csv", "id,question,answer\n", "no questions"},
+		{"qna.csv", "id,question,answer\n,a question,an answer\n", "row 2: id must not be empty"},
+		{"qna.csv", "id,question,answer\ngas-leak,a question,an answer\ngas-leak,another question,other answer\n", "row 3: qna gas-leak of locale en_US has a different answer"},
+		{"qna.yaml", "- id: gas-leak\n  questions: [a question]\n", "questions"},
+	}
+
+	for _, c := range cases {
+		_, err := ReadQnaFile(writeQnaFile(t, c.name, c.content))
+
+		if err == nil || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("%s %q: expected an error containing %q, got: %v", c.name, c.content, c.message, err)
+		}
+	}
+}
+
+func TestMissingQnaTranslations(t *testing.T) {
+
+	missing := MissingQnaTranslations([]LexQna{
+		{"gas-leak", "en_US", []string{"a"}, "a"},
+		{"gas-leak", "es_US", []string{"b"}, "b"},
+		{"password-reset", "en_US", []string{"c"}, "c"},
+		{"pilot-light", "fr_CA", []string{"d"}, "d"},
+	})
+
+	expected := "gas-leak: fr_CA|password-reset: es_US, fr_CA|pilot-light: en_US, es_US"
+
+	if strings.Join(missing, "|") != expected {
+		t.Errorf("unexpected missing translations: %v", missing)
+	}
+
+	if missing := MissingQnaTranslations(testQna[:2]); len(missing) != 0 {
+		t.Errorf("expected no missing translations, got: %v", missing)
+	}
+}
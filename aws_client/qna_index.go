@@ -0,0 +1,289 @@
+package aws_client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// an opensearch or elasticsearch index the fulfillment lambda looks up the
+// answers in
+type QnaIndex struct {
+	// e.g. http://localhost:9200 or the endpoint of an aws opensearch domain
+	Endpoint string
+	Index    string
+	// basic auth, e.g. for a local opensearch container
+	Username string
+	Password string
+	// sign requests with the provider credentials, for an aws opensearch domain
+	AwsAuth bool
+}
+
+// service name requests to aws opensearch domains are signed for
+const openSearchSigningName = "es"
+
+// a document of the index, one per qna id and locale
+type qnaDocument struct {
+	Qid       string   `json:"qid"`
+	Locale    string   `json:"locale"`
+	Questions []string `json:"questions"`
+	Answer    string   `json:"answer"`
+}
+
+// QnaDocumentId returns the id of the index document of a qna
+func QnaDocumentId(qna LexQna) string {
+	return qna.Id + ":" + qna.LocaleId
+}
+
+// ParseQnaAnswers reads the answers json of QnaAnswers back into qna pairs,
+// sorted by document id
+func ParseQnaAnswers(answers string) ([]LexQna, error) {
+
+	var parsed map[string]map[string]qnaAnswer
+
+	if err := json.Unmarshal([]byte(answers), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid qna answers: %s", err)
+	}
+
+	pairs := []LexQna{}
+
+	for id, locales := range parsed {
+		for localeId, answer := range locales {
+			pairs = append(pairs, LexQna{
+				Id:        id,
+				LocaleId:  localeId,
+				Questions: answer.Questions,
+				Answer:    answer.Answer,
+			})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return QnaDocumentId(pairs[i]) < QnaDocumentId(pairs[j])
+	})
+
+	return pairs, nil
+}
+
+// QnaDocumentHash returns the hash of the index document of a qna
+func QnaDocumentHash(qna LexQna) string {
+	return qnaDocumentHash(qnaDocument{qna.Id, qna.LocaleId, qna.Questions, qna.Answer})
+}
+
+func qnaDocumentHash(document qnaDocument) string {
+	b, _ := json.Marshal(document)
+	hash := sha256.Sum256(b)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// ReadQnaDocuments returns the hashes of the documents of ids found in the
+// index, keyed by document id. None are found if the index does not exist
+func (c *AwsClient) ReadQnaDocuments(ctx context.Context, index QnaIndex, ids []string) (map[string]string, error) {
+
+	hashes := make(map[string]string)
+
+	if len(ids) == 0 {
+		return hashes, nil
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"ids": ids})
+
+	var result struct {
+		Docs []struct {
+			Id     string      `json:"_id"`
+			Found  bool        `json:"found"`
+			Source qnaDocument `json:"_source"`
+		} `json:"docs"`
+	}
+
+	status, err := c.indexRequest(ctx, index, http.MethodPost, "/"+index.Index+"/_mget", "application/json", body, &result)
+
+	if status == http.StatusNotFound {
+		return hashes, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading documents of index %s: %s", index.Index, err)
+	}
+
+	for _, doc := range result.Docs {
+		if doc.Found {
+			hashes[doc.Id] = qnaDocumentHash(doc.Source)
+		}
+	}
+
+	return hashes, nil
+}
+
+// PutQnaDocuments upserts a document per qna in a single bulk request, and
+// deletes the documents of staleIds that are not among them
+func (c *AwsClient) PutQnaDocuments(ctx context.Context, index QnaIndex, pairs []LexQna, staleIds []string) error {
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+
+	ids := make(map[string]bool)
+
+	for _, qna := range pairs {
+
+		id := QnaDocumentId(qna)
+		ids[id] = true
+
+		encoder.Encode(map[string]interface{}{
+			"index": map[string]interface{}{"_index": index.Index, "_id": id},
+		})
+		encoder.Encode(qnaDocument{qna.Id, qna.LocaleId, qna.Questions, qna.Answer})
+	}
+
+	deleted := 0
+
+	for _, id := range staleIds {
+		if ids[id] {
+			continue
+		}
+
+		encoder.Encode(map[string]interface{}{
+			"delete": map[string]interface{}{"_index": index.Index, "_id": id},
+		})
+		deleted++
+	}
+
+	if body.Len() == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] writing %d and deleting %d documents of index %s\n", len(pairs), deleted, index.Index)
+
+	return c.bulk(ctx, index, body.Bytes())
+}
+
+// DeleteQnaDocuments deletes documents from the index, ignoring missing ones
+func (c *AwsClient) DeleteQnaDocuments(ctx context.Context, index QnaIndex, ids []string) error {
+
+	return c.PutQnaDocuments(ctx, index, nil, ids)
+}
+
+// send a bulk request, refreshing the index so the lambda sees the changes
+// right away
+func (c *AwsClient) bulk(ctx context.Context, index QnaIndex, body []byte) error {
+
+	var result struct {
+		Errors bool                        `json:"errors"`
+		Items  []map[string]bulkItemResult `json:"items"`
+	}
+
+	_, err := c.indexRequest(ctx, index, http.MethodPost, "/_bulk?refresh=true", "application/x-ndjson", body, &result)
+
+	if err != nil {
+		return fmt.Errorf("error writing documents of index %s: %s", index.Index, err)
+	}
+
+	if !result.Errors {
+		return nil
+	}
+
+	failures := []string{}
+
+	for _, item := range result.Items {
+		for action, itemResult := range item {
+
+			// the document was already gone
+			if action == "delete" && itemResult.Status == http.StatusNotFound {
+				continue
+			}
+
+			if itemResult.Error != nil {
+				failures = append(failures, fmt.Sprintf("%s %s: %s", action, itemResult.Id, itemResult.Error.Reason))
+			}
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("error writing documents of index %s: %s", index.Index, strings.Join(failures, ", "))
+}
+
+type bulkItemResult struct {
+	Id     string `json:"_id"`
+	Status int    `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+// send a request to the index endpoint, decoding a successful json response
+// into result. The status code is returned along with any error
+func (c *AwsClient) indexRequest(ctx context.Context, index QnaIndex, method string, path string,
+	contentType string, body []byte, result interface{}) (int, error) {
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(index.Endpoint, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	if index.Username != "" {
+		req.SetBasicAuth(index.Username, index.Password)
+	}
+
+	if index.AwsAuth {
+		if err = c.signIndexRequest(ctx, req, body); err != nil {
+			return 0, err
+		}
+	}
+
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+
+	b, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return rsp.StatusCode, err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return rsp.StatusCode, fmt.Errorf("request failed with response code: %d, %s", rsp.StatusCode, b)
+	}
+
+	if result == nil {
+		return rsp.StatusCode, nil
+	}
+
+	return rsp.StatusCode, json.Unmarshal(b, result)
+}
+
+// sign the request with the credentials of the provider
+func (c *AwsClient) signIndexRequest(ctx context.Context, req *http.Request, body []byte) error {
+
+	if c.Credentials == nil {
+		return fmt.Errorf("no aws credentials to sign the request with")
+	}
+
+	credentials, err := c.Credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(body)
+
+	return v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(hash[:]),
+		openSearchSigningName, c.Region, time.Now())
+}
@@ -0,0 +1,248 @@
+package aws_client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// a stand-in for the opensearch bulk and multi get apis, keeping the documents of
+// a single index
+func newTestIndexServer(t *testing.T, indexName string) (*httptest.Server, map[string]qnaDocument) {
+
+	documents := make(map[string]qnaDocument)
+	exists := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if user, password, _ := r.BasicAuth(); user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/_bulk":
+
+			if r.Header.Get("Content-Type") != "application/x-ndjson" || r.URL.Query().Get("refresh") != "true" {
+				t.Errorf("unexpected bulk request: %s %s", r.Header.Get("Content-Type"), r.URL)
+			}
+
+			items := []interface{}{}
+			hasErrors := false
+			scanner := bufio.NewScanner(r.Body)
+
+			for scanner.Scan() {
+				var action map[string]struct {
+					Index string `json:"_index"`
+					Id    string `json:"_id"`
+				}
+				json.Unmarshal(scanner.Bytes(), &action)
+
+				if meta, ok := action["index"]; ok {
+					scanner.Scan()
+					var document qnaDocument
+					json.Unmarshal(scanner.Bytes(), &document)
+
+					if document.Answer == "" {
+						hasErrors = true
+						items = append(items, map[string]interface{}{"index": map[string]interface{}{
+							"_id": meta.Id, "status": 400, "error": map[string]interface{}{"type": "mapper_parsing_exception", "reason": "no answer"}}})
+						continue
+					}
+
+					exists = true
+					documents[meta.Id] = document
+					items = append(items, map[string]interface{}{"index": map[string]interface{}{"_id": meta.Id, "status": 201}})
+				}
+
+				if meta, ok := action["delete"]; ok {
+					status := 200
+					if _, found := documents[meta.Id]; !found {
+						hasErrors = true
+						status = 404
+					}
+					delete(documents, meta.Id)
+					items = append(items, map[string]interface{}{"delete": map[string]interface{}{"_id": meta.Id, "status": status}})
+				}
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": hasErrors, "items": items})
+
+		case r.Method == "POST" && r.URL.Path == "/"+indexName+"/_mget" && exists:
+
+			var request struct {
+				Ids []string `json:"ids"`
+			}
+			json.NewDecoder(r.Body).Decode(&request)
+
+			docs := []interface{}{}
+			for _, id := range request.Ids {
+				if document, found := documents[id]; found {
+					docs = append(docs, map[string]interface{}{"_id": id, "found": true, "_source": document})
+				} else {
+					docs = append(docs, map[string]interface{}{"_id": id, "found": false})
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"docs": docs})
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"index_not_found_exception"},"status":404}`))
+		}
+	}))
+
+	return server, documents
+}
+
+func TestParseQnaAnswers(t *testing.T) {
+
+	answers, _ := QnaAnswers(testQna)
+
+	pairs, err := ParseQnaAnswers(answers)
+
+	if err != nil || len(pairs) != 3 {
+		t.Fatalf("expected 3 pairs, got: %v, err: %v", pairs, err)
+	}
+
+	if QnaDocumentId(pairs[0]) != "gas-leak:en_US" || QnaDocumentId(pairs[1]) != "gas-leak:es_US" ||
+		pairs[0].Answer != "Call 911" || len(pairs[0].Questions) != 2 {
+		t.Errorf("unexpected pairs: %v", pairs)
+	}
+
+	if _, err := ParseQnaAnswers("not json"); err == nil {
+		t.Errorf("expected an error for invalid answers")
+	}
+}
+
+func TestPutQnaDocuments(t *testing.T) {
+
+	server, documents := newTestIndexServer(t, "qna")
+	defer server.Close()
+
+	awsClient, _ := NewTestClient(MockBotClient{})
+	index := QnaIndex{Endpoint: server.URL + "/", Index: "qna", Username: "admin", Password: "secret"}
+
+	allIds := []string{"gas-leak:en_US", "gas-leak:es_US", "password-reset:en_US", "retired:en_US", "unmanaged:en_US"}
+
+	// a missing index has no documents
+	hashes, err := awsClient.ReadQnaDocuments(context.TODO(), index, allIds)
+
+	if err != nil || len(hashes) != 0 {
+		t.Fatalf("expected no documents, got: %v, err: %v", hashes, err)
+	}
+
+	documents["retired:en_US"] = qnaDocument{Qid: "retired", Locale: "en_US", Answer: "gone"}
+	documents["unmanaged:en_US"] = qnaDocument{Qid: "unmanaged", Locale: "en_US", Answer: "not ours"}
+
+	// only the stale ids are deleted, other documents of the index are kept
+	err = awsClient.PutQnaDocuments(context.TODO(), index, testQna, []string{"retired:en_US", "gas-leak:en_US"})
+
+	if err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	hashes, err = awsClient.ReadQnaDocuments(context.TODO(), index, allIds)
+
+	if err != nil || len(hashes) != 4 || hashes["retired:en_US"] != "" || hashes["unmanaged:en_US"] == "" {
+		t.Errorf("unexpected documents: %v, err: %v", hashes, err)
+	}
+
+	for _, qna := range testQna {
+		if hashes[QnaDocumentId(qna)] != QnaDocumentHash(qna) {
+			t.Errorf("expected the hash of %s to match the qna, got: %v", QnaDocumentId(qna), hashes)
+		}
+	}
+
+	// an answer edited outside of terraform changes the hash
+	edited := documents["gas-leak:en_US"]
+	edited.Answer = "Call the gas company"
+	documents["gas-leak:en_US"] = edited
+
+	hashes, _ = awsClient.ReadQnaDocuments(context.TODO(), index, []string{"gas-leak:en_US"})
+
+	if len(hashes) != 1 || hashes["gas-leak:en_US"] == QnaDocumentHash(testQna[0]) {
+		t.Errorf("expected the edited answer to change the hash, got: %v", hashes)
+	}
+
+	if documents["gas-leak:es_US"].Answer != "Llame al 911" || documents["gas-leak:es_US"].Locale != "es_US" {
+		t.Errorf("unexpected document: %+v", documents["gas-leak:es_US"])
+	}
+
+	// deleting a missing document is not an error
+	if err = awsClient.DeleteQnaDocuments(context.TODO(), index, []string{"gas-leak:es_US", "retired:en_US"}); err != nil {
+		t.Errorf("error should be nil: %s", err)
+	}
+
+	if _, found := documents["gas-leak:es_US"]; found || len(documents) != 3 {
+		t.Errorf("unexpected documents: %v", documents)
+	}
+
+	// failed documents are reported
+	err = awsClient.PutQnaDocuments(context.TODO(), index, []LexQna{{"broken", "en_US", []string{"a question"}, ""}}, nil)
+
+	if err == nil || !strings.Contains(err.Error(), "no answer") {
+		t.Errorf("expected the failed document to be reported, got: %v", err)
+	}
+
+	// as are failed requests
+	index.Password = "wrong"
+
+	if _, err = awsClient.ReadQnaDocuments(context.TODO(), index, allIds); err == nil {
+		t.Errorf("expected an error for invalid credentials")
+	}
+}
+
+func TestQnaIndexAwsAuth(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{})
+	index := QnaIndex{Endpoint: "http://localhost:9200", Index: "qna", AwsAuth: true}
+
+	if _, err := awsClient.ReadQnaDocuments(context.TODO(), index, []string{"gas-leak:en_US"}); err == nil {
+		t.Errorf("expected an error without credentials")
+	}
+}
+
+// runs against a real index, e.g. a local container started with
+//
+//	docker run -p 9200:9200 -e discovery.type=single-node -e DISABLE_SECURITY_PLUGIN=true opensearchproject/opensearch
+//	AWSLEX_OPENSEARCH_ENDPOINT=http://localhost:9200 go test -run TestQnaIndexOpenSearch ./...
+func TestQnaIndexOpenSearch(t *testing.T) {
+
+	endpoint := os.Getenv("AWSLEX_OPENSEARCH_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("AWSLEX_OPENSEARCH_ENDPOINT not set")
+	}
+
+	awsClient, _ := NewTestClient(MockBotClient{})
+	index := QnaIndex{
+		Endpoint: endpoint,
+		Index:    "awslex-test-qna",
+		Username: os.Getenv("AWSLEX_OPENSEARCH_USERNAME"),
+		Password: os.Getenv("AWSLEX_OPENSEARCH_PASSWORD"),
+	}
+
+	ids := []string{"gas-leak:en_US", "gas-leak:es_US", "password-reset:en_US"}
+
+	if err := awsClient.PutQnaDocuments(context.TODO(), index, testQna, nil); err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	if err := awsClient.PutQnaDocuments(context.TODO(), index, testQna[:1], ids); err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	hashes, err := awsClient.ReadQnaDocuments(context.TODO(), index, ids)
+
+	if err != nil || len(hashes) != 1 || hashes["gas-leak:en_US"] != QnaDocumentHash(testQna[0]) {
+		t.Errorf("unexpected documents: %v, err: %v", hashes, err)
+	}
+
+	if err = awsClient.DeleteQnaDocuments(context.TODO(), index, ids); err != nil {
+		t.Errorf("error should be nil: %s", err)
+	}
+}
@@ -0,0 +1,258 @@
+package aws_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testQna = []LexQna{
+	{"gas-leak", "en_US", []string{"I smell gas in my house", "emergency gas leak"}, "Call 911"},
+	{"password-reset", "en_US", []string{"I forgot my password"}, "Reset it in My Account"},
+	{"gas-leak", "es_US", []string{"Huelo gas en mi casa"}, "Llame al 911"},
+}
+
+func TestValidateQna(t *testing.T) {
+
+	cases := []struct {
+		pairs   []LexQna
+		isValid bool
+	}{
+		{testQna, true},
+		{[]LexQna{{"", "en_US", []string{"a question"}, "an answer"}}, false},
+		{[]LexQna{{"gas-leak", "en_US", []string{}, "an answer"}}, false},
+		{[]LexQna{{"gas-leak", "en_US", []string{"a question"}, ""}}, false},
+		{[]LexQna{{"gas-leak", "en_US", []string{" "}, "an answer"}}, false},
+		{[]LexQna{
+			{"gas-leak", "en_US", []string{"a question"}, "an answer"},
+			{"gas-leak", "en_US", []string{"another question"}, "an answer"},
+		}, false},
+		{[]LexQna{
+			{"gas-leak", "en_US", []string{"a question"}, "an answer"},
+			{"password-reset", "en_US", []string{"A Question"}, "an answer"},
+		}, false},
+		{[]LexQna{
+			{"gas-leak", "en_US", []string{"a question"}, "an answer"},
+			{"gas-leak", "fr_CA", []string{"a question"}, "an answer"},
+		}, true},
+		{[]LexQna{{"gas-leak", "fr_XX", []string{"a question"}, "an answer"}}, false},
+		{[]LexQna{{"gas-leak", "en_US", []string{strings.Repeat("a", 141)}, "an answer"}}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateQna(c.pairs)
+
+		if (err == nil) != c.isValid {
+			t.Errorf("qna %v: expected valid=%t, got err: %v", c.pairs, c.isValid, err)
+		}
+	}
+}
+
+func TestQnaAnswers(t *testing.T) {
+
+	answers, err := QnaAnswers(testQna)
+
+	if err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	var result map[string]map[string]qnaAnswer
+	json.Unmarshal([]byte(answers), &result)
+
+	if result["gas-leak"]["es_US"].Answer != "Llame al 911" || len(result["gas-leak"]["en_US"].Questions) != 2 ||
+		result["password-reset"]["en_US"].Answer != "Reset it in My Account" {
+		t.Errorf("unexpected answers: %s", answers)
+	}
+
+	path := filepath.Join(t.TempDir(), "artifacts", "answers.json")
+
+	if err = WriteQnaAnswers(path, testQna); err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	if b, _ := ioutil.ReadFile(path); string(b) != answers {
+		t.Errorf("unexpected answers file: %s", b)
+	}
+}
+
+func readTestArchive(t *testing.T, b []byte) map[string][]byte {
+
+	reader, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatalf("invalid archive: %s", err)
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range reader.File {
+		rc, _ := f.Open()
+		files[f.Name], _ = ioutil.ReadAll(rc)
+		rc.Close()
+	}
+
+	return files
+}
+
+func slotTypeValues(t *testing.T, b []byte) []string {
+
+	var slotType struct {
+		SlotTypeValues []struct {
+			SampleValue struct {
+				Value string `json:"value"`
+			} `json:"sampleValue"`
+		} `json:"slotTypeValues"`
+	}
+
+	if err := json.Unmarshal(b, &slotType); err != nil {
+		t.Fatalf("invalid slot type: %s", err)
+	}
+
+	values := []string{}
+	for _, v := range slotType.SlotTypeValues {
+		values = append(values, v.SampleValue.Value)
+	}
+
+	return values
+}
+
+func TestQnaArchive(t *testing.T) {
+
+	bot := LexBot{Name: "TerraBot", Description: "Terraform Bot", Qna: testQna}
+
+	b, err := QnaArchive(bot)
+
+	if err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	files := readTestArchive(t, b)
+
+	for _, name := range []string{
+		"Manifest.json",
+		"TerraBot/Bot.json",
+		"TerraBot/BotLocales/en_US/BotLocale.json",
+		"TerraBot/BotLocales/en_US/Intents/FallbackIntent/Intent.json",
+		"TerraBot/BotLocales/en_US/Intents/QnaIntent/Intent.json",
+		"TerraBot/BotLocales/en_US/Intents/QnaIntent/Slots/qnaslot/Slot.json",
+		"TerraBot/BotLocales/es_US/SlotTypes/QnaSlotType/SlotType.json",
+	} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("expected %s in the archive, got: %v", name, files)
+		}
+	}
+
+	values := slotTypeValues(t, files["TerraBot/BotLocales/en_US/SlotTypes/QnaSlotType/SlotType.json"])
+
+	if len(values) != 3 || values[0] != "I smell gas in my house" || values[2] != "I forgot my password" {
+		t.Errorf("unexpected slot type values: %v", values)
+	}
+
+	// the same content gives the same archive
+	hash, _ := QnaArchiveHash(bot)
+	other, _ := QnaArchiveHash(bot)
+
+	if hash == "" || hash != other {
+		t.Errorf("expected a stable hash, got: %s and %s", hash, other)
+	}
+}
+
+func TestQnaArchiveReplacesQna(t *testing.T) {
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"Manifest.json":                                                     `{}`,
+		"QnABot/Bot.json":                                                   `{"name":"QnABot"}`,
+		"QnABot/BotLocales/en_US/BotLocale.json":                            `{"identifier":"en_US"}`,
+		"QnABot/BotLocales/en_US/Intents/Greeting/Intent.json":              `{"name":"Greeting"}`,
+		"QnABot/BotLocales/en_US/Intents/QnaIntent/Slots/old/Slot.json":     `{"name":"old"}`,
+		"QnABot/BotLocales/en_US/SlotTypes/QnaSlotType/SlotType.json":       `{"slotTypeValues":[{"sampleValue":{"value":"exit"}}]}`,
+		"QnABot/BotLocales/fr_CA/SlotTypes/QnaSlotType/SlotType.json":       `{"slotTypeValues":[{"sampleValue":{"value":"quitter"}}]}`,
+		"QnABot/BotLocales/fr_CA/Intents/QnaIntent/Slots/qnaslot/Slot.json": `{"name":"qnaslot"}`,
+	} {
+		f, _ := writer.Create(name)
+		f.Write([]byte(content))
+	}
+	writer.Close()
+
+	archivePath := filepath.Join(t.TempDir(), "bot.zip")
+	os.WriteFile(archivePath, archive.Bytes(), 0644)
+
+	b, err := QnaArchive(LexBot{Name: "TerraBot", ArchivePath: archivePath, Qna: testQna})
+
+	if err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	files := readTestArchive(t, b)
+
+	if string(files["QnABot/BotLocales/en_US/BotLocale.json"]) != `{"identifier":"en_US"}` {
+		t.Errorf("expected the locale of the archive to be kept, got: %s", files["QnABot/BotLocales/en_US/BotLocale.json"])
+	}
+
+	if _, ok := files["QnABot/BotLocales/en_US/Intents/Greeting/Intent.json"]; !ok {
+		t.Errorf("expected other intents to be kept")
+	}
+
+	if _, ok := files["QnABot/BotLocales/en_US/Intents/QnaIntent/Slots/old/Slot.json"]; ok {
+		t.Errorf("expected the qna resources of the archive to be replaced")
+	}
+
+	if values := slotTypeValues(t, files["QnABot/BotLocales/en_US/SlotTypes/QnaSlotType/SlotType.json"]); len(values) != 3 {
+		t.Errorf("unexpected slot type values: %v", values)
+	}
+
+	// locales without qna are left as they are
+	if values := slotTypeValues(t, files["QnABot/BotLocales/fr_CA/SlotTypes/QnaSlotType/SlotType.json"]); len(values) != 1 || values[0] != "quitter" {
+		t.Errorf("unexpected slot type values: %v", values)
+	}
+
+	// locales missing from the archive are generated
+	if _, ok := files["QnABot/BotLocales/es_US/BotLocale.json"]; !ok {
+		t.Errorf("expected the es_US locale to be generated")
+	}
+
+	if _, err := QnaArchive(LexBot{Name: "TerraBot", ArchivePath: filepath.Join(t.TempDir(), "missing.zip"), Qna: testQna}); err == nil {
+		t.Errorf("expected an error for a missing archive")
+	}
+}
+
+func TestValidateQnaLocales(t *testing.T) {
+
+	// the en_US locale of the archive and the es_US pairs can be built
+	archivePath := filepath.Join(t.TempDir(), "bot.zip")
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for _, name := range []string{"Manifest.json", "QnABot/Bot.json", "QnABot/BotLocales/en_US/BotLocale.json"} {
+		f, _ := writer.Create(name)
+		f.Write([]byte(`{}`))
+	}
+	writer.Close()
+	os.WriteFile(archivePath, archive.Bytes(), 0644)
+
+	esPairs := testQna[2:]
+
+	cases := []struct {
+		bot     LexBot
+		isValid bool
+	}{
+		{LexBot{ArchivePath: archivePath, Qna: esPairs, Locales: []LexBotLocale{{LocaleId: "es_US"}}}, true},
+		// fr_CA is neither in the archive nor has pairs
+		{LexBot{ArchivePath: archivePath, Qna: esPairs, Locales: []LexBotLocale{{LocaleId: "fr_CA"}}}, false},
+		// without an archive, only the locales with pairs are generated
+		{LexBot{Name: "TerraBot", Qna: testQna}, true},
+		{LexBot{Name: "TerraBot", Qna: esPairs}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateQnaLocales(c.bot)
+
+		if (err == nil) != c.isValid {
+			t.Errorf("bot %+v: expected valid=%t, got err: %v", c.bot, c.isValid, err)
+		}
+	}
+}
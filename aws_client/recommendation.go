@@ -0,0 +1,259 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+// wait up to this many seconds for a bot recommendation, which can take hours
+// for large transcript sets
+const RecommendationWaitTimeoutSec = 6 * 60 * 60
+
+// RecommendationAvailable is true once lex has completed the recommendation
+func RecommendationAvailable(status string) bool {
+	return types.BotRecommendationStatus(status) == types.BotRecommendationStatusAvailable
+}
+
+// RecommendationRunning is true while the recommendation can still be stopped
+func RecommendationRunning(status string) bool {
+	switch types.BotRecommendationStatus(status) {
+	case types.BotRecommendationStatusProcessing,
+		types.BotRecommendationStatusDownloading,
+		types.BotRecommendationStatusUpdating:
+		return true
+	}
+	return false
+}
+
+// intents and slot types recommended by lex from conversation transcripts
+type LexBotRecommendation struct {
+	BotId    string
+	LocaleId string
+	// transcripts in the lex transcript format, under the prefixes of the bucket
+	S3BucketName   string
+	ObjectPrefixes []string
+	// key the transcripts are encrypted with, if any
+	TranscriptKmsKeyArn string
+	// key to encrypt the recommendation results with, if any
+	KmsKeyArn string
+
+	Id            string
+	Status        string
+	IntentCount   int
+	SlotTypeCount int
+	// names of the recommended intents and slot types
+	Intents   []string
+	SlotTypes []string
+}
+
+// StartBotRecommendation starts a recommendation for the draft version of the
+// bot locale and waits for it to complete. The recommended locale is unpacked
+// into outputDir in the import/export layout, unless outputDir is empty
+func (c *AwsClient) StartBotRecommendation(ctx context.Context, recommendation *LexBotRecommendation, outputDir string) error {
+
+	source := &types.S3BucketTranscriptSource{
+		S3BucketName:     &recommendation.S3BucketName,
+		TranscriptFormat: types.TranscriptFormatLex,
+		PathFormat: &types.PathFormat{
+			ObjectPrefixes: recommendation.ObjectPrefixes,
+		},
+	}
+
+	if recommendation.TranscriptKmsKeyArn != "" {
+		source.KmsKeyArn = &recommendation.TranscriptKmsKeyArn
+	}
+
+	input := &lexmodelsv2.StartBotRecommendationInput{
+		BotId:      &recommendation.BotId,
+		BotVersion: getAddr(DraftVersion),
+		LocaleId:   &recommendation.LocaleId,
+		TranscriptSourceSetting: &types.TranscriptSourceSetting{
+			S3BucketTranscriptSource: source,
+		},
+	}
+
+	if recommendation.KmsKeyArn != "" {
+		input.EncryptionSetting = &types.EncryptionSetting{
+			KmsKeyArn: &recommendation.KmsKeyArn,
+		}
+	}
+
+	startOutput, err := c.Client.StartBotRecommendation(ctx, input)
+
+	if err != nil {
+		return fmt.Errorf("error starting recommendation for locale %s of bot %s: %s", recommendation.LocaleId, recommendation.BotId, err)
+	}
+
+	recommendation.Id = aws.ToString(startOutput.BotRecommendationId)
+
+	description, err := c.recommendationWait(ctx, recommendation)
+
+	if err != nil {
+		return err
+	}
+
+	recommendation.Status = string(description.BotRecommendationStatus)
+
+	if results := description.BotRecommendationResults; results != nil {
+
+		if results.Statistics != nil && results.Statistics.Intents != nil {
+			recommendation.IntentCount = int(aws.ToInt32(results.Statistics.Intents.DiscoveredIntentCount))
+		}
+
+		if results.Statistics != nil && results.Statistics.SlotTypes != nil {
+			recommendation.SlotTypeCount = int(aws.ToInt32(results.Statistics.SlotTypes.DiscoveredSlotTypeCount))
+		}
+
+		if results.BotLocaleExportUrl != nil {
+			return c.downloadRecommendation(ctx, recommendation, *results.BotLocaleExportUrl, outputDir)
+		}
+	}
+
+	return nil
+}
+
+// wait for the recommendation to leave the processing states
+func (c *AwsClient) recommendationWait(ctx context.Context, recommendation *LexBotRecommendation) (*lexmodelsv2.DescribeBotRecommendationOutput, error) {
+
+	expiredTimeSec := 0
+	sleepDurationSec := 60
+	for {
+		description, err := c.describeBotRecommendation(ctx, recommendation.BotId, recommendation.LocaleId, recommendation.Id)
+
+		if err != nil {
+			return description, err
+		}
+
+		switch description.BotRecommendationStatus {
+		case types.BotRecommendationStatusAvailable:
+			return description, nil
+		case types.BotRecommendationStatusFailed, types.BotRecommendationStatusStopped:
+			return description, fmt.Errorf("recommendation %s %s: %s", recommendation.Id,
+				strings.ToLower(string(description.BotRecommendationStatus)), strings.Join(description.FailureReasons, ", "))
+		}
+
+		if expiredTimeSec >= RecommendationWaitTimeoutSec {
+			return description, fmt.Errorf("recommendation %s did not complete within %d seconds", recommendation.Id, RecommendationWaitTimeoutSec)
+		}
+
+		log.Printf("[DEBUG] waiting for bot recommendation to complete. Current status: %s\n", description.BotRecommendationStatus)
+
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return description, err
+		}
+		expiredTimeSec += sleepDurationSec
+	}
+}
+
+// DescribeBotRecommendation returns the status of the recommendation
+func (c *AwsClient) DescribeBotRecommendation(ctx context.Context, botId string, localeId string, id string) (string, error) {
+
+	description, err := c.describeBotRecommendation(ctx, botId, localeId, id)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(description.BotRecommendationStatus), nil
+}
+
+func (c *AwsClient) describeBotRecommendation(ctx context.Context, botId string, localeId string, id string) (*lexmodelsv2.DescribeBotRecommendationOutput, error) {
+
+	description, err := c.Client.DescribeBotRecommendation(ctx, &lexmodelsv2.DescribeBotRecommendationInput{
+		BotId:               &botId,
+		BotVersion:          getAddr(DraftVersion),
+		LocaleId:            &localeId,
+		BotRecommendationId: &id,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error describing recommendation %s: %w", id, err)
+	}
+
+	return description, nil
+}
+
+// StopBotRecommendation stops a recommendation that is still running
+func (c *AwsClient) StopBotRecommendation(ctx context.Context, botId string, localeId string, id string) error {
+
+	_, err := c.Client.StopBotRecommendation(ctx, &lexmodelsv2.StopBotRecommendationInput{
+		BotId:               &botId,
+		BotVersion:          getAddr(DraftVersion),
+		LocaleId:            &localeId,
+		BotRecommendationId: &id,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error stopping recommendation %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// download the recommended locale, listing its intents and slot types
+func (c *AwsClient) downloadRecommendation(ctx context.Context, recommendation *LexBotRecommendation, exportUrl string, outputDir string) error {
+
+	tempDir, err := ioutil.TempDir("", "awslex-recommendation")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	archivePath := filepath.Join(tempDir, "recommendation.zip")
+
+	if err = c.download(ctx, exportUrl, archivePath); err != nil {
+		return fmt.Errorf("error downloading recommendation %s: %s", recommendation.Id, err)
+	}
+
+	dir := outputDir
+	if dir == "" {
+		dir = filepath.Join(tempDir, "recommendation")
+	}
+
+	if err = Unzip(archivePath, dir); err != nil {
+		return fmt.Errorf("error unpacking recommendation %s: %s", recommendation.Id, err)
+	}
+
+	recommendation.Intents, recommendation.SlotTypes, err = listArchiveResources(dir)
+
+	return err
+}
+
+// names of the intents and slot types of an unpacked import/export archive
+func listArchiveResources(dir string) ([]string, []string, error) {
+
+	intents := []string{}
+	slotTypes := []string{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		switch info.Name() {
+		case "Intent.json":
+			intents = append(intents, filepath.Base(filepath.Dir(path)))
+		case "SlotType.json":
+			slotTypes = append(slotTypes, filepath.Base(filepath.Dir(path)))
+		}
+
+		return nil
+	})
+
+	sort.Strings(intents)
+	sort.Strings(slotTypes)
+
+	return intents, slotTypes, err
+}
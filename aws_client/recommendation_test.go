@@ -0,0 +1,141 @@
+package aws_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+)
+
+// a lex client sending its requests to the test server
+func newServerTestClient(url string) *AwsClient {
+	return &AwsClient{
+		Client: lexmodelsv2.New(lexmodelsv2.Options{
+			Region:           "us-west-2",
+			Credentials:      aws.AnonymousCredentials{},
+			EndpointResolver: lexmodelsv2.EndpointResolverFromURL(url),
+		}),
+		AccountId: "abcd",
+		Region:    "us-west-2",
+	}
+}
+
+func TestStartBotRecommendation(t *testing.T) {
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for _, name := range []string{
+		"BotLocales/en_US/Intents/CheckBalance/Intent.json",
+		"BotLocales/en_US/Intents/ReportLeak/Intent.json",
+		"BotLocales/en_US/SlotTypes/AccountType/SlotType.json",
+	} {
+		f, _ := writer.Create(name)
+		f.Write([]byte(`{}`))
+	}
+	writer.Close()
+
+	var startBody map[string]interface{}
+
+	server := httptest.NewServer(nil)
+	defer server.Close()
+
+	path := "/bots/BOTID/botversions/DRAFT/botlocales/en_US/botrecommendations"
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == path:
+			json.NewDecoder(r.Body).Decode(&startBody)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"botRecommendationId":"RECID","botRecommendationStatus":"Processing"}`))
+		case r.Method == "GET" && r.URL.Path == path+"/RECID":
+			w.Write([]byte(`{"botRecommendationId":"RECID","botRecommendationStatus":"Available",
+				"botRecommendationResults":{"botLocaleExportUrl":"` + server.URL + `/export.zip",
+				"statistics":{"intents":{"discoveredIntentCount":2},"slotTypes":{"discoveredSlotTypeCount":1}}}}`))
+		case r.Method == "GET" && r.URL.Path == "/export.zip":
+			w.Write(archive.Bytes())
+		default:
+			w.Header().Set("X-Amzn-ErrorType", "ResourceNotFoundException")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	})
+
+	awsClient := newServerTestClient(server.URL)
+
+	recommendation := LexBotRecommendation{
+		BotId:               "BOTID",
+		LocaleId:            "en_US",
+		S3BucketName:        "transcripts",
+		ObjectPrefixes:      []string{"contact-center/"},
+		TranscriptKmsKeyArn: "some-key-arn",
+	}
+
+	outputDir := filepath.Join(t.TempDir(), "recommendation")
+
+	err := awsClient.StartBotRecommendation(context.TODO(), &recommendation, outputDir)
+
+	if err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	source := startBody["transcriptSourceSetting"].(map[string]interface{})["s3BucketTranscriptSource"].(map[string]interface{})
+	if source["s3BucketName"] != "transcripts" || source["kmsKeyArn"] != "some-key-arn" || source["transcriptFormat"] != "Lex" {
+		t.Errorf("unexpected transcript source: %v", source)
+	}
+
+	if recommendation.Id != "RECID" || recommendation.Status != "Available" ||
+		recommendation.IntentCount != 2 || recommendation.SlotTypeCount != 1 {
+		t.Errorf("unexpected recommendation: %+v", recommendation)
+	}
+
+	if len(recommendation.Intents) != 2 || recommendation.Intents[0] != "CheckBalance" ||
+		len(recommendation.SlotTypes) != 1 || recommendation.SlotTypes[0] != "AccountType" {
+		t.Errorf("unexpected intents %v and slot types %v", recommendation.Intents, recommendation.SlotTypes)
+	}
+
+	if _, err := ArchiveHash(filepath.Join(outputDir, "BotLocales/en_US/Intents/ReportLeak/Intent.json")); err != nil {
+		t.Errorf("expected the recommendation to be unpacked: %s", err)
+	}
+
+	// errors are deserialized into the modelled types
+	_, err = awsClient.DescribeBotRecommendation(context.TODO(), "BOTID", "en_US", "OTHERID")
+
+	if !IsNotFoundError(err) {
+		t.Errorf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestListArchiveResources(t *testing.T) {
+
+	intents, slotTypes, err := listArchiveResources(t.TempDir())
+
+	if err != nil || len(intents) != 0 || len(slotTypes) != 0 {
+		t.Errorf("expected no resources, got: %v, %v, err: %v", intents, slotTypes, err)
+	}
+}
+
+func TestRecommendationStatus(t *testing.T) {
+
+	for _, status := range []string{"Processing", "Downloading", "Updating"} {
+		if !RecommendationRunning(status) || RecommendationAvailable(status) {
+			t.Errorf("expected a %s recommendation to be running", status)
+		}
+	}
+
+	for _, status := range []string{"Available", "Failed", "Stopping", "Stopped", "Deleted"} {
+		if RecommendationRunning(status) {
+			t.Errorf("expected a %s recommendation not to be running", status)
+		}
+	}
+
+	if !RecommendationAvailable("Available") {
+		t.Errorf("expected an Available recommendation to be available")
+	}
+}
@@ -0,0 +1,45 @@
+package aws_client
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+)
+
+const RetryModeStandard = "standard"
+const RetryModeAdaptive = "adaptive"
+
+// lex rejects imports, builds and versions started while the bot or locale
+// is still being imported, built or versioned. these clear once the running
+// operation completes. elsewhere, e.g. creating a bot whose name is taken, a
+// conflict is final, so only these calls retry it.
+func retryConflict(o *lexmodelsv2.Options) {
+	o.Retryer = retry.AddWithErrorCodes(o.Retryer, "ConflictException")
+}
+
+// builds and versions fail with a precondition failure until the draft they
+// are based on settles. elsewhere, e.g. for a stale resource policy
+// revision, the failure is final, so only these calls retry it.
+func retryPreconditionFailed(o *lexmodelsv2.Options) {
+	o.Retryer = retry.AddWithErrorCodes(o.Retryer, "PreconditionFailedException")
+}
+
+// newRetryer creates the sdk retryer for the retry mode. nil max retries
+// keeps the sdk default, zero disables retries.
+func newRetryer(maxRetries *int, retryMode string) func() aws.Retryer {
+
+	standardOptions := func(o *retry.StandardOptions) {
+		if maxRetries != nil {
+			o.MaxAttempts = *maxRetries + 1
+		}
+	}
+
+	return func() aws.Retryer {
+		if retryMode == RetryModeAdaptive {
+			return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+				o.StandardOptions = append(o.StandardOptions, standardOptions)
+			})
+		}
+		return retry.NewStandard(standardOptions)
+	}
+}
@@ -0,0 +1,60 @@
+package aws_client
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestRetryer(t *testing.T) {
+
+	maxRetries := 7
+
+	for _, mode := range []string{RetryModeStandard, RetryModeAdaptive} {
+
+		retryer := newRetryer(&maxRetries, mode)()
+
+		if retryer.MaxAttempts() != 8 {
+			t.Errorf("%s: expected 8 attempts, got %d", mode, retryer.MaxAttempts())
+		}
+
+		conflict := &types.ConflictException{Message: getAddr("bot is currently being built")}
+		if retryer.IsErrorRetryable(fmt.Errorf("wrapped: %w", conflict)) {
+			t.Errorf("%s: expected conflict not to be retryable by default", mode)
+		}
+
+		if retryer.IsErrorRetryable(&types.PreconditionFailedException{}) {
+			t.Errorf("%s: expected precondition failure not to be retryable by default", mode)
+		}
+
+		options := lexmodelsv2.Options{Retryer: retryer}
+		retryConflict(&options)
+		if !options.Retryer.IsErrorRetryable(fmt.Errorf("wrapped: %w", conflict)) {
+			t.Errorf("%s: expected conflict to be retryable for imports, builds and versions", mode)
+		}
+
+		retryPreconditionFailed(&options)
+		if !options.Retryer.IsErrorRetryable(&types.PreconditionFailedException{}) {
+			t.Errorf("%s: expected precondition failure to be retryable for builds and versions", mode)
+		}
+
+		if !retryer.IsErrorRetryable(&types.ThrottlingException{}) {
+			t.Errorf("%s: expected throttling to be retryable", mode)
+		}
+
+		if retryer.IsErrorRetryable(&types.ValidationException{}) {
+			t.Errorf("%s: expected validation errors not to be retryable", mode)
+		}
+	}
+
+	if newRetryer(nil, RetryModeStandard)().MaxAttempts() != 3 {
+		t.Error("expected the sdk default attempts when max retries is not set")
+	}
+
+	noRetries := 0
+	if newRetryer(&noRetries, RetryModeStandard)().MaxAttempts() != 1 {
+		t.Error("expected a single attempt when max retries is zero")
+	}
+}
@@ -0,0 +1,153 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+)
+
+// tags managed outside terraform, e.g. by the org's tagging automation
+type IgnoreTags struct {
+	Keys        []string
+	KeyPrefixes []string
+}
+
+func (t IgnoreTags) ignored(key string) bool {
+	for _, k := range t.Keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range t.KeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeDefaultTags adds the provider default tags to the resource tags.
+// resource tags take precedence.
+func (c *AwsClient) MergeDefaultTags(tags map[string]string) map[string]string {
+	result := make(map[string]string)
+	for key, val := range c.DefaultTags {
+		result[key] = val
+	}
+	for key, val := range tags {
+		result[key] = val
+	}
+	return result
+}
+
+// RemoveIgnoredTags drops the tags configured to be ignored
+func (c *AwsClient) RemoveIgnoredTags(tags map[string]string) map[string]string {
+	result := make(map[string]string)
+	for key, val := range tags {
+		if !c.IgnoreTags.ignored(key) {
+			result[key] = val
+		}
+	}
+	return result
+}
+
+// RemoveDefaultTags drops the tags that come from the provider default tags,
+// unless the resource configures them itself
+func (c *AwsClient) RemoveDefaultTags(tags map[string]string, resourceTags map[string]string) map[string]string {
+	result := make(map[string]string)
+	for key, val := range tags {
+		defaultVal, isDefault := c.DefaultTags[key]
+		_, isResourceTag := resourceTags[key]
+		if isDefault && defaultVal == val && !isResourceTag {
+			continue
+		}
+		result[key] = val
+	}
+	return result
+}
+
+func (c *AwsClient) listTags(ctx context.Context, resourceArn string) (map[string]string, error) {
+
+	listTagsForResourceOutput, err := c.Client.ListTagsForResource(ctx,
+		&lexmodelsv2.ListTagsForResourceInput{
+			ResourceARN: &resourceArn,
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	for key, val := range listTagsForResourceOutput.Tags {
+		tags[key] = val
+	}
+
+	return tags, nil
+}
+
+// updateTags makes the tags of a resource match the given tags, leaving the
+// ignored tags alone
+func (c *AwsClient) updateTags(ctx context.Context, resourceArn string, tags map[string]string) error {
+
+	currentTags, err := c.listTags(ctx, resourceArn)
+
+	if err != nil {
+		return fmt.Errorf("error listing tags of %s: %s", resourceArn, err)
+	}
+
+	addTags, removeKeys := diffTags(c.RemoveIgnoredTags(currentTags), tags)
+
+	if len(removeKeys) > 0 {
+		_, err = c.Client.UntagResource(ctx, &lexmodelsv2.UntagResourceInput{
+			ResourceARN: &resourceArn,
+			TagKeys:     removeKeys,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error removing tags from %s: %s", resourceArn, err)
+		}
+	}
+
+	if len(addTags) > 0 {
+		_, err = c.Client.TagResource(ctx, &lexmodelsv2.TagResourceInput{
+			ResourceARN: &resourceArn,
+			Tags:        addTags,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error adding tags to %s: %s", resourceArn, err)
+		}
+	}
+
+	return nil
+}
+
+// diffTags returns the tags to add (or change) and the tag keys to remove
+func diffTags(oldTags map[string]string, newTags map[string]string) (map[string]string, []string) {
+
+	addTags := make(map[string]string)
+	for key, val := range newTags {
+		if oldVal, ok := oldTags[key]; !ok || oldVal != val {
+			addTags[key] = val
+		}
+	}
+
+	removeKeys := []string{}
+	for key := range oldTags {
+		if _, ok := newTags[key]; !ok {
+			removeKeys = append(removeKeys, key)
+		}
+	}
+	sort.Strings(removeKeys)
+
+	return addTags, removeKeys
+}
+
+// tagsMatch reports whether two resources have the same tags, not counting
+// the ignored ones
+func (c *AwsClient) tagsMatch(tags map[string]string, otherTags map[string]string) bool {
+	return reflect.DeepEqual(c.RemoveIgnoredTags(tags), c.RemoveIgnoredTags(otherTags))
+}
@@ -0,0 +1,101 @@
+package aws_client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{})
+	awsClient.DefaultTags = map[string]string{
+		"unit":          "shcva",
+		"support-group": "SCGMA Team",
+	}
+	awsClient.IgnoreTags = IgnoreTags{
+		Keys:        []string{"LastScanned"},
+		KeyPrefixes: []string{"org:"},
+	}
+
+	resourceTags := map[string]string{
+		"name":          "scg-shcva Virtual Assistant",
+		"support-group": "Other Team",
+	}
+
+	merged := awsClient.MergeDefaultTags(resourceTags)
+
+	expected := map[string]string{
+		"name":          "scg-shcva Virtual Assistant",
+		"unit":          "shcva",
+		"support-group": "Other Team",
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected merged tags %v, got %v", expected, merged)
+	}
+
+	// tags as read back from aws, including tags added by automation
+	awsTags := map[string]string{
+		"name":          "scg-shcva Virtual Assistant",
+		"unit":          "shcva",
+		"support-group": "Other Team",
+		"LastScanned":   "2021-12-01",
+		"org:owner":     "cloud-team",
+	}
+
+	tagsAll := awsClient.RemoveIgnoredTags(awsTags)
+	if !reflect.DeepEqual(tagsAll, expected) {
+		t.Errorf("expected ignored tags to be removed, got %v", tagsAll)
+	}
+
+	tags := awsClient.RemoveDefaultTags(tagsAll, resourceTags)
+	if !reflect.DeepEqual(tags, resourceTags) {
+		t.Errorf("expected default tags to be removed, got %v", tags)
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+
+	oldTags := map[string]string{
+		"name":    "scg-shcva Virtual Assistant",
+		"unit":    "shcva",
+		"retired": "yes",
+	}
+	newTags := map[string]string{
+		"name":          "scg-shcva Virtual Assistant",
+		"unit":          "scgma",
+		"support-group": "SCGMA Team",
+	}
+
+	addTags, removeKeys := diffTags(oldTags, newTags)
+
+	expectedAdd := map[string]string{
+		"unit":          "scgma",
+		"support-group": "SCGMA Team",
+	}
+	if !reflect.DeepEqual(addTags, expectedAdd) {
+		t.Errorf("expected tags to add %v, got %v", expectedAdd, addTags)
+	}
+	if !reflect.DeepEqual(removeKeys, []string{"retired"}) {
+		t.Errorf("expected tag keys to remove [retired], got %v", removeKeys)
+	}
+}
+
+func TestTagsMatch(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{})
+	awsClient.IgnoreTags = IgnoreTags{KeyPrefixes: []string{"org:"}}
+
+	botTags := map[string]string{"name": "bot", "unit": "shcva", "org:owner": "platform"}
+
+	if !awsClient.tagsMatch(botTags, map[string]string{"name": "bot", "unit": "shcva"}) {
+		t.Errorf("expected tags that only differ in ignored tags to match")
+	}
+
+	if awsClient.tagsMatch(botTags, map[string]string{"name": "bot", "unit": "scgma"}) {
+		t.Errorf("expected tags with another value not to match")
+	}
+
+	if awsClient.tagsMatch(botTags, map[string]string{"name": "bot"}) {
+		t.Errorf("expected missing tags not to match")
+	}
+}
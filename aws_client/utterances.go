@@ -0,0 +1,161 @@
+package aws_client
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+// utterances of a bot alias or version, aggregated over a time window
+type LexUtteranceFilter struct {
+	BotId string
+	// exactly one of the alias id or the version
+	BotAliasId string
+	BotVersion string
+	LocaleId   string
+	// one of AggregationDurations, e.g. 24h
+	Duration string
+	// only utterances containing this text, when not empty
+	Contains string
+	// only utterances lex did not recognize at least once
+	MissedOnly bool
+}
+
+type LexUtterance struct {
+	Utterance     string
+	HitCount      int
+	MissedCount   int
+	FirstRecorded time.Time
+	LastRecorded  time.Time
+}
+
+// the relative time windows lex aggregates utterances over
+var aggregationDurations = map[string]types.RelativeAggregationDuration{
+	"1h":  {TimeDimension: types.TimeDimensionHours, TimeValue: 1},
+	"3h":  {TimeDimension: types.TimeDimensionHours, TimeValue: 3},
+	"6h":  {TimeDimension: types.TimeDimensionHours, TimeValue: 6},
+	"12h": {TimeDimension: types.TimeDimensionHours, TimeValue: 12},
+	"24h": {TimeDimension: types.TimeDimensionHours, TimeValue: 24},
+	"3d":  {TimeDimension: types.TimeDimensionDays, TimeValue: 3},
+	"1w":  {TimeDimension: types.TimeDimensionWeeks, TimeValue: 1},
+	"2w":  {TimeDimension: types.TimeDimensionWeeks, TimeValue: 2},
+}
+
+// AggregationDurations returns the time windows utterances can be listed for
+func AggregationDurations() []string {
+	return []string{"1h", "3h", "6h", "12h", "24h", "3d", "1w", "2w"}
+}
+
+// ListAggregatedUtterances returns the utterances matching the filter, most
+// missed first
+func (c *AwsClient) ListAggregatedUtterances(ctx context.Context, filter LexUtteranceFilter) ([]LexUtterance, error) {
+
+	duration, ok := aggregationDurations[filter.Duration]
+	if !ok {
+		return nil, fmt.Errorf("unknown aggregation duration %s", filter.Duration)
+	}
+
+	input := &lexmodelsv2.ListAggregatedUtterancesInput{
+		BotId:    &filter.BotId,
+		LocaleId: &filter.LocaleId,
+		AggregationDuration: &types.UtteranceAggregationDuration{
+			RelativeAggregationDuration: &duration,
+		},
+		SortBy: &types.AggregatedUtterancesSortBy{
+			Attribute: types.AggregatedUtterancesSortAttributeMissedCount,
+			Order:     types.SortOrderDescending,
+		},
+	}
+
+	if filter.BotAliasId != "" {
+		input.BotAliasId = &filter.BotAliasId
+	} else {
+		input.BotVersion = &filter.BotVersion
+	}
+
+	if filter.Contains != "" {
+		input.Filters = []types.AggregatedUtterancesFilter{
+			{
+				Name:     types.AggregatedUtterancesFilterNameUtterance,
+				Operator: types.AggregatedUtterancesFilterOperatorContains,
+				Values:   []string{filter.Contains},
+			},
+		}
+	}
+
+	utterances := []LexUtterance{}
+
+	paginator := lexmodelsv2.NewListAggregatedUtterancesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+
+		listAggregatedUtterancesOutput, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("error listing utterances of bot %s: %s", filter.BotId, err)
+		}
+
+		for _, summary := range listAggregatedUtterancesOutput.AggregatedUtterancesSummaries {
+
+			utterance := LexUtterance{
+				Utterance:     aws.ToString(summary.Utterance),
+				HitCount:      int(aws.ToInt32(summary.HitCount)),
+				MissedCount:   int(aws.ToInt32(summary.MissedCount)),
+				FirstRecorded: aws.ToTime(summary.UtteranceFirstRecordedInAggregationDuration),
+				LastRecorded:  aws.ToTime(summary.UtteranceLastRecordedInAggregationDuration),
+			}
+
+			if filter.MissedOnly && utterance.MissedCount == 0 {
+				continue
+			}
+
+			utterances = append(utterances, utterance)
+		}
+	}
+
+	return utterances, nil
+}
+
+// WriteUtterancesCsv writes the utterances to a csv file with a header line
+func WriteUtterancesCsv(path string, utterances []LexUtterance) error {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+
+	writer.Write([]string{"utterance", "hit_count", "missed_count", "first_recorded", "last_recorded"})
+
+	for _, utterance := range utterances {
+		writer.Write([]string{
+			utterance.Utterance,
+			strconv.Itoa(utterance.HitCount),
+			strconv.Itoa(utterance.MissedCount),
+			utterance.FirstRecorded.Format(time.RFC3339),
+			utterance.LastRecorded.Format(time.RFC3339),
+		})
+	}
+
+	writer.Flush()
+
+	if err = writer.Error(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
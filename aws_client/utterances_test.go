@@ -0,0 +1,78 @@
+package aws_client
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestListAggregatedUtterances(t *testing.T) {
+
+	recorded := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		ListAggregatedUtterancesOutput: lexmodelsv2.ListAggregatedUtterancesOutput{
+			AggregatedUtterancesSummaries: []types.AggregatedUtterancesSummary{
+				{
+					Utterance:   getAddr("my pilot light is out"),
+					HitCount:    aws.Int32(1),
+					MissedCount: aws.Int32(4),
+					UtteranceFirstRecordedInAggregationDuration: &recorded,
+					UtteranceLastRecordedInAggregationDuration:  &recorded,
+				},
+				{Utterance: getAddr("forgot password"), HitCount: aws.Int32(9), MissedCount: aws.Int32(0)},
+			},
+		},
+	})
+
+	filter := LexUtteranceFilter{BotId: "BOTID", BotAliasId: "ALIASID", LocaleId: "en_US", Duration: "1w"}
+
+	utterances, err := awsClient.ListAggregatedUtterances(context.TODO(), filter)
+
+	if err != nil || len(utterances) != 2 {
+		t.Fatalf("expected 2 utterances, got: %v, err: %v", utterances, err)
+	}
+
+	filter.MissedOnly = true
+
+	utterances, err = awsClient.ListAggregatedUtterances(context.TODO(), filter)
+
+	if err != nil || len(utterances) != 1 || utterances[0].MissedCount != 4 || utterances[0].HitCount != 1 {
+		t.Fatalf("expected the missed utterance, got: %v, err: %v", utterances, err)
+	}
+
+	filter.Duration = "5d"
+
+	if _, err := awsClient.ListAggregatedUtterances(context.TODO(), filter); err == nil {
+		t.Errorf("expected an error for an unknown duration")
+	}
+
+	path := filepath.Join(t.TempDir(), "missed", "utterances.csv")
+
+	if err := WriteUtterancesCsv(path, utterances); err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	b, _ := ioutil.ReadFile(path)
+	expected := "utterance,hit_count,missed_count,first_recorded,last_recorded\n" +
+		"my pilot light is out,1,4,2026-10-01T12:00:00Z,2026-10-01T12:00:00Z\n"
+
+	if string(b) != expected {
+		t.Errorf("unexpected csv: %q", b)
+	}
+}
+
+func TestAggregationDurations(t *testing.T) {
+
+	for _, duration := range AggregationDurations() {
+		if _, ok := aggregationDurations[duration]; !ok {
+			t.Errorf("duration %s is not mapped to a lex duration", duration)
+		}
+	}
+}
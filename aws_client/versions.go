@@ -0,0 +1,116 @@
+package aws_client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+)
+
+type LexBotVersion struct {
+	Version string
+	Status  string
+	Created time.Time
+	// the description of a version is used to store the source code hash
+	SourceCodeHash string
+}
+
+type LexBotAlias struct {
+	Id      string
+	Name    string
+	Version string
+	Status  string
+	// lambda of each locale, keyed by locale id
+	LambdaArns map[string]string
+	Tags       map[string]string
+}
+
+// ListBotVersions returns every version of the bot, including the draft
+func (c *AwsClient) ListBotVersions(ctx context.Context, botId string) ([]LexBotVersion, error) {
+
+	versions := []LexBotVersion{}
+
+	paginator := lexmodelsv2.NewListBotVersionsPaginator(c.Client, &lexmodelsv2.ListBotVersionsInput{
+		BotId: &botId,
+	})
+
+	for paginator.HasMorePages() {
+
+		listBotVersionsOutput, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("error listing versions of bot %s: %s", botId, err)
+		}
+
+		for _, summary := range listBotVersionsOutput.BotVersionSummaries {
+			versions = append(versions, LexBotVersion{
+				Version:        aws.ToString(summary.BotVersion),
+				Status:         string(summary.BotStatus),
+				Created:        aws.ToTime(summary.CreationDateTime),
+				SourceCodeHash: aws.ToString(summary.Description),
+			})
+		}
+	}
+
+	return versions, nil
+}
+
+// ListBotAliases returns every alias of the bot, with its lambdas and tags
+func (c *AwsClient) ListBotAliases(ctx context.Context, botId string) ([]LexBotAlias, error) {
+
+	aliases := []LexBotAlias{}
+
+	paginator := lexmodelsv2.NewListBotAliasesPaginator(c.Client, &lexmodelsv2.ListBotAliasesInput{
+		BotId: &botId,
+	})
+
+	for paginator.HasMorePages() {
+
+		listBotAliasesOutput, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("error listing aliases of bot %s: %s", botId, err)
+		}
+
+		for _, summary := range listBotAliasesOutput.BotAliasSummaries {
+
+			alias := LexBotAlias{
+				Id:         aws.ToString(summary.BotAliasId),
+				Name:       aws.ToString(summary.BotAliasName),
+				Version:    aws.ToString(summary.BotVersion),
+				Status:     string(summary.BotAliasStatus),
+				LambdaArns: make(map[string]string),
+			}
+
+			// the summary does not include the locale settings
+			describeBotAliasOutput, err := c.Client.DescribeBotAlias(ctx, &lexmodelsv2.DescribeBotAliasInput{
+				BotId:      &botId,
+				BotAliasId: &alias.Id,
+			})
+
+			if err != nil {
+				return nil, fmt.Errorf("error describing bot alias %s: %s", alias.Id, err)
+			}
+
+			for localeId, settings := range describeBotAliasOutput.BotAliasLocaleSettings {
+				if settings.CodeHookSpecification != nil && settings.CodeHookSpecification.LambdaCodeHook != nil {
+					alias.LambdaArns[localeId] = aws.ToString(settings.CodeHookSpecification.LambdaCodeHook.LambdaARN)
+				}
+			}
+
+			tags, err := c.listTags(ctx, c.AliasArn(botId, alias.Id))
+
+			if err != nil {
+				return nil, fmt.Errorf("error listing tags of bot alias %s: %s", alias.Id, err)
+			}
+
+			alias.Tags = c.RemoveIgnoredTags(tags)
+
+			aliases = append(aliases, alias)
+		}
+	}
+
+	return aliases, nil
+}
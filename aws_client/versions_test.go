@@ -0,0 +1,74 @@
+package aws_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestListBotVersions(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		ListBotVersionsOutput: lexmodelsv2.ListBotVersionsOutput{
+			BotVersionSummaries: []types.BotVersionSummary{
+				{BotVersion: getAddr(DraftVersion), BotStatus: types.BotStatusAvailable},
+				{BotVersion: getAddr("1"), BotStatus: types.BotStatusAvailable, Description: getAddr("some-hash")},
+			},
+		},
+	})
+
+	versions, err := awsClient.ListBotVersions(context.TODO(), "BOTID")
+
+	if err != nil || len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got: %v, err: %v", versions, err)
+	}
+
+	if versions[1].Version != "1" || versions[1].SourceCodeHash != "some-hash" || versions[1].Status != "Available" {
+		t.Errorf("unexpected version: %+v", versions[1])
+	}
+}
+
+func TestListBotAliases(t *testing.T) {
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		ListBotAliasesOutput: lexmodelsv2.ListBotAliasesOutput{
+			BotAliasSummaries: []types.BotAliasSummary{
+				{
+					BotAliasId:     getAddr("ALIASID"),
+					BotAliasName:   getAddr("latest"),
+					BotVersion:     getAddr("1"),
+					BotAliasStatus: types.BotAliasStatusAvailable,
+				},
+			},
+		},
+		DescribeBotAliasOutput: lexmodelsv2.DescribeBotAliasOutput{
+			BotAliasLocaleSettings: map[string]types.BotAliasLocaleSettings{
+				"en_US": {
+					CodeHookSpecification: &types.CodeHookSpecification{
+						LambdaCodeHook: &types.LambdaCodeHook{
+							LambdaARN: getAddr("some-lambda-arn"),
+						},
+					},
+					Enabled: true,
+				},
+			},
+		},
+		ListTagsForResourceOutput: lexmodelsv2.ListTagsForResourceOutput{
+			Tags: map[string]string{"unit": "shcva"},
+		},
+	})
+
+	aliases, err := awsClient.ListBotAliases(context.TODO(), "BOTID")
+
+	if err != nil || len(aliases) != 1 {
+		t.Fatalf("expected 1 alias, got: %v, err: %v", aliases, err)
+	}
+
+	alias := aliases[0]
+
+	if alias.Name != "latest" || alias.Version != "1" || alias.LambdaArns["en_US"] != "some-lambda-arn" || alias.Tags["unit"] != "shcva" {
+		t.Errorf("unexpected alias: %+v", alias)
+	}
+}
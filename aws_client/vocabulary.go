@@ -0,0 +1,289 @@
+package aws_client
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+// a phrase that speech recognition should favor, e.g. "pilot light"
+type LexVocabularyItem struct {
+	Phrase string
+	// between 0 and 3, zero means no weight
+	Weight int
+}
+
+const MaxVocabularyWeight = 3
+
+// name of the vocabulary file in the uploaded archive
+const vocabularyFileName = "CustomVocabulary.tsv"
+
+// ReadVocabularyFile reads a tab separated file of phrases and optional weights,
+// with a `phrase` and `weight` header line
+func ReadVocabularyFile(path string) ([]LexVocabularyItem, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	items := []LexVocabularyItem{}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+
+	for scanner.Scan() {
+
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		columns := strings.Split(line, "\t")
+
+		if lineNumber == 1 && strings.EqualFold(strings.TrimSpace(columns[0]), "phrase") {
+			continue
+		}
+
+		if len(columns) > 2 {
+			return nil, fmt.Errorf("%s line %d: expected a phrase and an optional weight", path, lineNumber)
+		}
+
+		item := LexVocabularyItem{Phrase: strings.TrimSpace(columns[0])}
+
+		if len(columns) == 2 && strings.TrimSpace(columns[1]) != "" {
+			item.Weight, err = strconv.Atoi(strings.TrimSpace(columns[1]))
+			if err != nil {
+				return nil, fmt.Errorf("%s line %d: invalid weight %q", path, lineNumber, columns[1])
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ValidateVocabulary checks for empty or repeated phrases and weights out of range
+func ValidateVocabulary(items []LexVocabularyItem) error {
+
+	seen := make(map[string]bool)
+
+	for _, item := range items {
+
+		if item.Phrase == "" {
+			return fmt.Errorf("custom vocabulary phrases must not be empty")
+		}
+
+		if strings.ContainsAny(item.Phrase, "\t\n") {
+			return fmt.Errorf("custom vocabulary phrase %q contains a tab or a new line", item.Phrase)
+		}
+
+		phrase := strings.ToLower(item.Phrase)
+		if seen[phrase] {
+			return fmt.Errorf("custom vocabulary phrase %q is listed more than once", item.Phrase)
+		}
+		seen[phrase] = true
+
+		if item.Weight < 0 || item.Weight > MaxVocabularyWeight {
+			return fmt.Errorf("weight of custom vocabulary phrase %q must be between 0 and %d", item.Phrase, MaxVocabularyWeight)
+		}
+	}
+
+	return nil
+}
+
+// the vocabulary of the locale, from the file or the inline phrases
+func (locale LexBotLocale) vocabulary() ([]LexVocabularyItem, error) {
+
+	if locale.CustomVocabularyFile == "" {
+		return locale.CustomVocabulary, nil
+	}
+
+	items, err := ReadVocabularyFile(locale.CustomVocabularyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading custom vocabulary of locale %s: %s", locale.LocaleId, err)
+	}
+
+	return items, nil
+}
+
+// zip archive containing the vocabulary as a tab separated file
+func vocabularyArchive(items []LexVocabularyItem) ([]byte, error) {
+
+	var tsv bytes.Buffer
+	tsv.WriteString("phrase\tweight\n")
+
+	for _, item := range items {
+		if item.Weight == 0 {
+			fmt.Fprintf(&tsv, "%s\t\n", item.Phrase)
+		} else {
+			fmt.Fprintf(&tsv, "%s\t%d\n", item.Phrase, item.Weight)
+		}
+	}
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+
+	file, err := writer.Create(vocabularyFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = file.Write(tsv.Bytes()); err != nil {
+		return nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return archive.Bytes(), nil
+}
+
+// import the custom vocabulary of each locale that has one into the draft
+// version of the bot. The locale needs to be built afterwards
+func (c *AwsClient) importCustomVocabularies(ctx context.Context, bot *LexBot) error {
+
+	for _, locale := range bot.Locales {
+
+		items, err := locale.vocabulary()
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			continue
+		}
+
+		if err = ValidateVocabulary(items); err != nil {
+			return fmt.Errorf("invalid custom vocabulary for locale %s: %s", locale.LocaleId, err)
+		}
+
+		archive, err := vocabularyArchive(items)
+		if err != nil {
+			return err
+		}
+
+		uploadId, err := c.uploadBytes(ctx, archive)
+		if err != nil {
+			return fmt.Errorf("error uploading custom vocabulary of locale %s: %s", locale.LocaleId, err)
+		}
+
+		log.Printf("[DEBUG] importing %d custom vocabulary phrases into locale %s\n", len(items), locale.LocaleId)
+
+		_, err = c.Client.StartImport(ctx, &lexmodelsv2.StartImportInput{
+			ImportId:      &uploadId,
+			MergeStrategy: types.MergeStrategyOverwrite,
+			ResourceSpecification: &types.ImportResourceSpecification{
+				CustomVocabularyImportSpecification: &types.CustomVocabularyImportSpecification{
+					BotId:      &bot.Id,
+					BotVersion: getAddr(DraftVersion),
+					LocaleId:   getAddr(locale.LocaleId),
+				},
+			},
+		}, retryConflict)
+
+		if err != nil {
+			return fmt.Errorf("error importing custom vocabulary of locale %s: %s", locale.LocaleId, err)
+		}
+
+		if err = c.importWait(ctx, uploadId); err != nil {
+			return fmt.Errorf("error importing custom vocabulary of locale %s: %s", locale.LocaleId, err)
+		}
+	}
+
+	return nil
+}
+
+// delete the custom vocabulary of each locale that configured one before the
+// update but no longer does. The locale needs to be built afterwards
+func (c *AwsClient) deleteRemovedVocabularies(ctx context.Context, bot *LexBot) error {
+
+	configured := make(map[string]bool)
+	for _, locale := range bot.Locales {
+		configured[locale.LocaleId] = locale.hasVocabulary()
+	}
+
+	for _, locale := range bot.PreviousLocales {
+
+		if !locale.hasVocabulary() || configured[locale.LocaleId] {
+			continue
+		}
+
+		log.Printf("[DEBUG] deleting custom vocabulary of locale %s\n", locale.LocaleId)
+
+		_, err := c.Client.DeleteCustomVocabulary(ctx, &lexmodelsv2.DeleteCustomVocabularyInput{
+			BotId:      &bot.Id,
+			BotVersion: getAddr(DraftVersion),
+			LocaleId:   getAddr(locale.LocaleId),
+		})
+
+		// already deleted, e.g. by a re-import of the archive
+		if IsNotFoundError(err) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("error deleting custom vocabulary of locale %s: %s", locale.LocaleId, err)
+		}
+
+		if err = c.vocabularyDeleteWait(ctx, bot, locale.LocaleId); err != nil {
+			return fmt.Errorf("error deleting custom vocabulary of locale %s: %s", locale.LocaleId, err)
+		}
+	}
+
+	return nil
+}
+
+// wait for the custom vocabulary of the locale to be deleted, so the locale
+// can be built
+func (c *AwsClient) vocabularyDeleteWait(ctx context.Context, bot *LexBot, localeId string) error {
+
+	expiredTimeSec := 0
+	sleepDurationSec := 10
+	for {
+		describeOutput, err := c.Client.DescribeCustomVocabularyMetadata(ctx, &lexmodelsv2.DescribeCustomVocabularyMetadataInput{
+			BotId:      &bot.Id,
+			BotVersion: getAddr(DraftVersion),
+			LocaleId:   &localeId,
+		})
+
+		// break if the vocabulary is gone
+		if IsNotFoundError(err) ||
+			(err == nil && describeOutput.CustomVocabularyStatus != types.CustomVocabularyStatusDeleting) {
+			return nil
+		}
+
+		if expiredTimeSec >= BotWaitTimeoutSec {
+			return fmt.Errorf("custom vocabulary was not deleted within %d seconds", BotWaitTimeoutSec)
+		}
+
+		if err == nil {
+			log.Printf("[DEBUG] waiting for custom vocabulary deletion to complete. Current status: %s\n", describeOutput.CustomVocabularyStatus)
+		}
+
+		// sleep for X seconds, stopping if the operation is cancelled
+		if err := sleepContext(ctx, time.Duration(sleepDurationSec)*time.Second); err != nil {
+			return err
+		}
+		expiredTimeSec += sleepDurationSec
+	}
+}
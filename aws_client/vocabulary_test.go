@@ -0,0 +1,167 @@
+package aws_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2"
+	"github.com/aws/aws-sdk-go-v2/service/lexmodelsv2/types"
+)
+
+func TestReadVocabularyFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "vocabulary.tsv")
+	os.WriteFile(path, []byte("phrase\tweight\r\npilot light\t3\nmeter ID\n\nSoCalGas\t\n"), 0644)
+
+	items, err := ReadVocabularyFile(path)
+
+	if err != nil || len(items) != 3 {
+		t.Fatalf("expected 3 phrases, got: %v, err: %v", items, err)
+	}
+
+	if items[0] != (LexVocabularyItem{"pilot light", 3}) || items[1] != (LexVocabularyItem{"meter ID", 0}) {
+		t.Errorf("unexpected phrases: %v", items)
+	}
+
+	os.WriteFile(path, []byte("pilot light\thigh\n"), 0644)
+
+	if _, err := ReadVocabularyFile(path); err == nil {
+		t.Errorf("expected an error for an invalid weight")
+	}
+}
+
+func TestValidateVocabulary(t *testing.T) {
+
+	cases := []struct {
+		items   []LexVocabularyItem
+		isValid bool
+	}{
+		{[]LexVocabularyItem{{"pilot light", 3}, {"meter ID", 0}}, true},
+		{[]LexVocabularyItem{{"", 1}}, false},
+		{[]LexVocabularyItem{{"pilot light", 4}}, false},
+		{[]LexVocabularyItem{{"pilot light", 1}, {"Pilot Light", 2}}, false},
+		{[]LexVocabularyItem{{"pilot\tlight", 1}}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateVocabulary(c.items)
+
+		if (err == nil) != c.isValid {
+			t.Errorf("vocabulary %v: expected valid=%t, got err: %v", c.items, c.isValid, err)
+		}
+	}
+}
+
+func TestImportCustomVocabularies(t *testing.T) {
+
+	var uploaded []byte
+	var inputs []interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploaded, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	awsClient, _ := NewTestClient(MockBotClient{
+		CreateUploadUrlOutput: lexmodelsv2.CreateUploadUrlOutput{
+			ImportId:  getAddr("IMPORTID"),
+			UploadUrl: getAddr(server.URL + "/vocabulary.zip"),
+		},
+		DescribeImportOutput: lexmodelsv2.DescribeImportOutput{
+			ImportStatus: types.ImportStatusCompleted,
+		},
+		inputs: &inputs,
+	})
+
+	bot := LexBot{
+		Id: "BOTID",
+		Locales: []LexBotLocale{
+			{LocaleId: "en_US", CustomVocabulary: []LexVocabularyItem{{"pilot light", 3}, {"meter ID", 0}}},
+			{LocaleId: "es_US"},
+		},
+	}
+
+	err := awsClient.importCustomVocabularies(context.TODO(), &bot)
+
+	if err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(uploaded), int64(len(uploaded)))
+	if err != nil || len(reader.File) != 1 || reader.File[0].Name != vocabularyFileName {
+		t.Fatalf("expected an archive with the vocabulary file, err: %v", err)
+	}
+
+	f, _ := reader.File[0].Open()
+	tsv, _ := ioutil.ReadAll(f)
+
+	if string(tsv) != "phrase\tweight\npilot light\t3\nmeter ID\t\n" {
+		t.Errorf("unexpected vocabulary file: %q", tsv)
+	}
+
+	var specification *types.CustomVocabularyImportSpecification
+	for _, input := range inputs {
+		if startImport, ok := input.(*lexmodelsv2.StartImportInput); ok {
+			specification = startImport.ResourceSpecification.CustomVocabularyImportSpecification
+		}
+	}
+
+	if specification == nil || *specification.BotId != "BOTID" || *specification.BotVersion != DraftVersion || *specification.LocaleId != "en_US" {
+		t.Errorf("expected a custom vocabulary import into the en_US draft, got: %+v", specification)
+	}
+}
+
+func TestDeleteRemovedVocabularies(t *testing.T) {
+
+	var inputs []interface{}
+
+	awsClient, _ := NewTestClient(MockBotClient{inputs: &inputs})
+
+	bot := LexBot{
+		Id: "BOTID",
+		Locales: []LexBotLocale{
+			{LocaleId: "en_US", CustomVocabulary: []LexVocabularyItem{{"pilot light", 3}}},
+			{LocaleId: "es_US"},
+		},
+		PreviousLocales: []LexBotLocale{
+			{LocaleId: "en_US", CustomVocabulary: []LexVocabularyItem{{"meter ID", 0}}},
+			{LocaleId: "es_US", CustomVocabularyFile: "vocabulary/es_US.tsv"},
+			{LocaleId: "fr_CA", CustomVocabulary: []LexVocabularyItem{{"veilleuse", 0}}},
+			{LocaleId: "en_GB"},
+		},
+	}
+
+	if err := awsClient.deleteRemovedVocabularies(context.TODO(), &bot); err != nil {
+		t.Fatalf("error should be nil: %s", err)
+	}
+
+	deleted := []string{}
+	for _, input := range inputs {
+		if deleteInput, ok := input.(*lexmodelsv2.DeleteCustomVocabularyInput); ok {
+			if *deleteInput.BotId != "BOTID" || *deleteInput.BotVersion != DraftVersion {
+				t.Errorf("expected the draft vocabulary to be deleted, got: %+v", deleteInput)
+			}
+			deleted = append(deleted, *deleteInput.LocaleId)
+		}
+	}
+
+	// the vocabularies of removed locale blocks are deleted as well
+	if strings.Join(deleted, ",") != "es_US,fr_CA" {
+		t.Errorf("expected the removed vocabularies to be deleted, got: %v", deleted)
+	}
+
+	// a vocabulary that is already gone is not an error
+	awsClient, _ = NewTestClient(MockBotClient{err: &types.ResourceNotFoundException{}})
+
+	if err := awsClient.deleteRemovedVocabularies(context.TODO(), &bot); err != nil {
+		t.Errorf("error should be nil: %s", err)
+	}
+}
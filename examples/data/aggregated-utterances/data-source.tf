@@ -0,0 +1,22 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_resource" "qnabot" {
+  name  = "TerraBot"
+  alias = "latest"
+}
+
+# utterances the bot missed last week, to feed back into the QnA list
+data "awslex_aggregated_utterances" "missed" {
+  bot_id       = data.awslex_bot_resource.qnabot.id
+  bot_alias_id = data.awslex_bot_resource.qnabot.alias_id
+  locale_id    = "en_US"
+  time_window  = "1w"
+  missed_only  = true
+  output_path  = "${path.module}/missed-utterances.csv"
+}
+
+output "most_missed" {
+  value = [for u in data.awslex_aggregated_utterances.missed.utterances : u.utterance if u.missed_count >= 5]
+}
@@ -0,0 +1,17 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_aliases" "qnabot" {
+  bot_id = "C5H22UIPWC"
+}
+
+# what each alias points to
+output "aliases" {
+  value = {
+    for a in data.awslex_bot_aliases.qnabot.aliases : a.name => {
+      version = a.version
+      lambda  = lookup(a.lambda_arns, "en_US", null)
+    }
+  }
+}
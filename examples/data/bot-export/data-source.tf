@@ -0,0 +1,15 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+# back up the bot edited in the console, unpacked so it can be committed to git
+data "awslex_bot_export" "qnabot" {
+  bot_id      = "C5H22UIPWC"
+  bot_version = "DRAFT"
+  output_path = "${path.module}/backup/qnabot.zip"
+  unpack_dir  = "${path.module}/backup/sources"
+}
+
+output "backup_hash" {
+  value = data.awslex_bot_export.qnabot.output_base64sha256
+}
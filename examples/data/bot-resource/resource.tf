@@ -13,8 +13,9 @@ provider "awslex" {
 
 data "awslex_bot_resource" "socal_gas_qnabot" {
 
-  # id and alias for stable dev
-  id = "C5H22UIPWC"
+  # name and alias for stable dev. the id (e.g. "C5H22UIPWC") changes
+  # when the bot is recreated, the name does not
+  name  = "TerraBot"
   alias = "latest"
 
 }
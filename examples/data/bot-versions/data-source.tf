@@ -0,0 +1,14 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_versions" "qnabot" {
+  bot_id = "C5H22UIPWC"
+}
+
+# the latest numbered version, e.g. as a rollback target
+output "latest_version" {
+  value = max([
+    for v in data.awslex_bot_versions.qnabot.versions : tonumber(v.version) if v.version != "DRAFT"
+  ]...)
+}
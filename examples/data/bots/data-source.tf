@@ -0,0 +1,17 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+# bots left behind by feature branch runs
+data "awslex_bots" "feature_branches" {
+  name_prefix = "qnabot-feature-"
+  status      = "Available"
+
+  tags = {
+    unit = "shcva"
+  }
+}
+
+output "feature_branch_bots" {
+  value = data.awslex_bots.feature_branches.bots
+}
@@ -0,0 +1,11 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_builtin_intents" "es_US" {
+  locale_id = "es_US"
+}
+
+output "has_fallback_intent" {
+  value = contains(data.awslex_builtin_intents.es_US.signatures, "AMAZON.FallbackIntent")
+}
@@ -0,0 +1,11 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_builtin_slot_types" "fr_CA" {
+  locale_id = "fr_CA"
+}
+
+output "has_number" {
+  value = contains(data.awslex_builtin_slot_types.fr_CA.signatures, "AMAZON.Number")
+}
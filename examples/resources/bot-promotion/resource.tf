@@ -0,0 +1,36 @@
+# the prod account
+provider "awslex" {
+  region = "us-west-2"
+
+  assume_role {
+    role_arn = "arn:aws:iam::222222222222:role/awslex-prod"
+  }
+}
+
+variable "dev_bot_version" {
+  type        = string
+  description = "Version of the dev bot that passed testing"
+}
+
+data "awslex_bot_resource" "prod" {
+  name  = "TerraBot"
+  alias = "latest"
+}
+
+# promote the exact version tested in the dev account to prod
+resource "awslex_bot_promotion" "prod" {
+  source {
+    bot_id      = "C5H22UIPWC"
+    bot_version = var.dev_bot_version
+    region      = "us-west-2"
+    role_arn    = "arn:aws:iam::111365482541:role/awslex-export"
+  }
+
+  bot_id     = data.awslex_bot_resource.prod.id
+  alias      = "live"
+  lambda_arn = "arn:aws:lambda:us-west-2:222222222222:function:scg-geeou-prod-wus2-lambda-fulfillment"
+}
+
+output "prod_version" {
+  value = awslex_bot_promotion.prod.version
+}
@@ -0,0 +1,22 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+# suggest intents from the contact-center transcripts
+resource "awslex_bot_recommendation" "contact_center" {
+  bot_id    = "C5H22UIPWC"
+  locale_id = "en_US"
+
+  transcript_source {
+    s3_bucket_name  = "scg-lexbot-dev-wus2-transcripts"
+    object_prefixes = ["contact-center/2026/"]
+    kms_key_arn     = "arn:aws:kms:us-west-2:111365482541:key/3f1b6c2e-8d1a-4c0e-9a57-0f6b2d1e7c44"
+  }
+
+  # review the recommended intents and slot types next to the bot sources
+  output_dir = "${path.module}/recommendations/en_US"
+}
+
+output "recommended_intents" {
+  value = awslex_bot_recommendation.contact_center.intents
+}
@@ -17,49 +17,26 @@ terraform {
 provider "awslex" {
   region = "us-west-2"
   // this should error out when testing locally
-  // role_arn = "arn:aws:iam::123456789012:role/awslex-role"
+  // assume_role {
+  //   role_arn = "arn:aws:iam::123456789012:role/awslex-role"
+  // }
 }
 
-provider "aws" {
-  region = "us-west-2"
-}
-
-data "aws_caller_identity" "current" {}
-
 locals {
   bot_name = "TerraBot"
   bot_description      = "Terraform Bot"
   lambda_arn           = "arn:aws:lambda:us-west-2:111365482541:function:scg-geeou-dev-wus2-lambda-fulfillment-dev"
-  account_id           = data.aws_caller_identity.current.account_id
   bot_id               = awslex_bot_resource.socal_gas_qnabot.id
   bot_alias_id         = awslex_bot_resource.socal_gas_qnabot.alias_id
 }
 
-# create the file that represents the Lex bot sources
+# create the file that represents the Lex bot sources. The QnA intent, slot and
+# slot type of the locales with question and answer pairs are generated by the
+# provider
 module "bot_sources" {
   source          = "./sources"
   bot_description = local.bot_description
-  bot_name = local.bot_name
-  intents = [
-    {
-      id = "gas-leak"
-      questions = ["I smell gas in my house. What should I do?",
-        "help my gas is leaking",
-        "smell garlic in my home and I'm not cooking",
-      "emergency gas leak"]
-
-      answer = "For Gas Emergencies or Safety Issues call Emergencies: 911 For general safety issues: 1-800-427-2200"
-    },
-    {
-      id = "password-reset"
-      questions = ["how do I reset my password",
-        "I forgot my password",
-        "Can't remember my password",
-      "My login does not work"]
-
-      answer = "If you forgot your My Account password, securely reset it with an authorization code that is sent to your cellphone number on your My Account profile."
-    }
-  ]
+  bot_name        = local.bot_name
 }
 
 resource "awslex_bot_resource" "socal_gas_qnabot" {
@@ -72,8 +49,14 @@ resource "awslex_bot_resource" "socal_gas_qnabot" {
   # path to the bot sources zip file, in bot import/export format
   archive_path = module.bot_sources.archive_path
 
-  # detect changes to the bot sources and update the bot
-  source_code_hash = module.bot_sources.archive_sha
+  # question and answer pairs in every locale, maintained by the content team
+  # in a spreadsheet. The QnA intent, slot and slot type of each locale are
+  # generated into the archive, and the source code hash is computed from it.
+  # qna blocks can be added to the pairs of the file
+  qna_file = "${path.module}/qna/qna.csv"
+
+  # answers keyed by qna id and locale, packaged with the fulfillment lambda
+  qna_answers_path = "${path.module}/artifacts/answers.json"
 
   # version of the bot
   alias = "latest"
@@ -81,8 +64,55 @@ resource "awslex_bot_resource" "socal_gas_qnabot" {
   # arn of the lambda that fulfills the bot intents
   lambda_arn = local.lambda_arn
 
+  # give the bot alias permission to invoke the lambda
+  manage_lambda_permission = true
+
+  # continue with the bot left behind by a branch run that lost its state
+  adopt_existing = true
+
+  # optional, defaults to a lex service-linked role created for the bot
   iam_role = "arn:aws:iam::111365482541:role/scg-lexbot-dev-wus2-iam-role-qnabot-dev"
 
+  # tune fallback rates and the voice channel per locale
+  locale {
+    locale_id                = "en_US"
+    nlu_confidence_threshold = 0.5
+    voice_id                 = "Joanna"
+    voice_engine             = "neural"
+
+    # utility jargon that voice conversations get wrong
+    custom_vocabulary {
+      phrase = "pilot light"
+      weight = 3
+    }
+
+    custom_vocabulary {
+      phrase = "meter ID"
+      weight = 2
+    }
+
+    custom_vocabulary {
+      phrase = "SoCalGas"
+    }
+  }
+
+  locale {
+    locale_id = "es_US"
+    voice_id  = "Lupe"
+
+    # phrases and weights maintained in a tab separated file
+    custom_vocabulary_file = "${path.module}/vocabulary/es_US.tsv"
+    custom_vocabulary_hash = filebase64sha256("${path.module}/vocabulary/es_US.tsv")
+  }
+
+  # the qna file has pairs for fr_CA too, so it is built and served by the
+  # alias like the other locales
+  locale {
+    locale_id    = "fr_CA"
+    voice_id     = "Gabrielle"
+    voice_engine = "neural"
+  }
+
   tags = {
     name                = "scg-shcva Virtual Assistant"
     tag-version         = "1.0.0"
@@ -94,19 +124,10 @@ resource "awslex_bot_resource" "socal_gas_qnabot" {
   }
 }
 
-// give the all aliases associated with this bot permission to invoke the lambda
-resource "aws_lambda_permission" "bot_permission" {
-
-  depends_on = [awslex_bot_resource.socal_gas_qnabot]
-
-  statement_id  = "AllowExecutionFromTerraBot"
-  action        = "lambda:InvokeFunction"
-  function_name = local.lambda_arn
-  principal     = "lexv2.amazonaws.com"
-  source_arn    = "arn:aws:lex:us-west-2:${local.account_id}:bot-alias/${local.bot_id}/*"
-}
-
 output "test_suggestion" {
   depends_on = [awslex_bot_resource.socal_gas_qnabot]
   value      = "aws lexv2-runtime recognize-text --bot-id '${local.bot_id}' --bot-alias-id '${awslex_bot_resource.socal_gas_qnabot.alias_id}' --locale-id 'en_US' --session-id 'test_session' --text 'forgot password'"
-}
\ No newline at end of file
+}
+output "alias_arn" {
+  value = awslex_bot_resource.socal_gas_qnabot.alias_arn
+}
@@ -8,25 +8,6 @@ variable "bot_description" {
   description = "The description of the bot"
 }
 
-variable "intents" {
-  type = list(object({
-    id        = string
-    questions = list(string)
-    answer    = string
-  }))
-}
-
-locals {
-  slot_type_values = flatten([for s in var.intents : [for q in s.questions : { "sampleValue" : { "value" : q }, "synonyms" : null }]])
-}
-
-data "template_file" "slot_types" {
-  template = file("${path.module}/QnABot/BotLocales/en_US/SlotTypes/QnaSlotType/SlotType.json.tmpl")
-  vars = {
-    slot_types = jsonencode(local.slot_type_values)
-  }
-}
-
 data "template_file" "bot_json" {
   template = file("${path.module}/QnABot/Bot.json.tmpl")
   vars = {
@@ -44,11 +25,6 @@ data "archive_file" "bot" {
     content  = data.template_file.bot_json.rendered
     filename = "${var.bot_name}/Bot.json"
   }
-  source {
-    content  = data.template_file.slot_types.rendered
-    filename = "${var.bot_name}/BotLocales/en_US/SlotTypes/QnaSlotType/SlotType.json"
-  }
-
 
   # content that is not templated
   source {
@@ -71,6 +47,10 @@ data "archive_file" "bot" {
     content  = file("${path.module}/QnABot/BotLocales/en_US/Intents/QnaIntent/Intent.json")
     filename = "${var.bot_name}/BotLocales/en_US/Intents/QnaIntent/Intent.json"
   }
+  source {
+    content  = file("${path.module}/QnABot/BotLocales/en_US/SlotTypes/QnaSlotType/SlotType.json")
+    filename = "${var.bot_name}/BotLocales/en_US/SlotTypes/QnaSlotType/SlotType.json"
+  }
   source {
     content  = file("${path.module}/QnABot/BotLocales/es_US/BotLocale.json")
     filename = "${var.bot_name}/BotLocales/es_US/BotLocale.json"
@@ -113,12 +93,6 @@ data "archive_file" "bot" {
   }
 }
 
-# for debugging
-# resource "local_file" "question_answer_pairs" {
-#     content     = jsonencode(var.intents)
-#     filename = "${path.module}/artifacts/pairs.json"
-# }
-
 output "archive_path" {
   value = data.archive_file.bot.output_path
 }
@@ -0,0 +1,22 @@
+provider "awslex" {
+  region = "us-west-2"
+}
+
+# publish the answers of the bot's qna blocks to the index the fulfillment
+# lambda searches, so the bot and the lambda never get out of sync
+resource "awslex_qna_index" "socal_gas_qnabot" {
+  endpoint = "https://search-scg-qnabot-dev-wus2.us-west-2.es.amazonaws.com"
+  index    = "qna"
+
+  qna_answers = awslex_bot_resource.socal_gas_qnabot.qna_answers
+
+  # sign requests with the provider credentials
+  aws_auth = true
+}
+
+# for local testing against an OpenSearch container
+# resource "awslex_qna_index" "local" {
+#   endpoint    = "http://localhost:9200"
+#   index       = "qna"
+#   qna_answers = file("${path.module}/artifacts/answers.json")
+# }
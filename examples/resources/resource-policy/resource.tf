@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    awslex = {
+      source  = "localhost/va/awslex"
+      version = "0.2.0-beta3"
+    }
+  }
+}
+
+provider "awslex" {
+  region = "us-west-2"
+}
+
+locals {
+  contact_center_account_id = "123456789012"
+  bot_alias_arn             = "arn:aws:lex:us-west-2:111365482541:bot-alias/C5H22UIPWC/TSTALIASID"
+}
+
+# allow the contact center account to call the bot alias
+resource "awslex_resource_policy" "contact_center" {
+  resource_arn = local.bot_alias_arn
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Sid       = "ContactCenterRecognizeText"
+      Effect    = "Allow"
+      Principal = { AWS = "arn:aws:iam::${local.contact_center_account_id}:root" }
+      Action    = ["lex:RecognizeText"]
+      Resource  = local.bot_alias_arn
+    }]
+  })
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scg/va/aws_client"
+)
+
+func dataSourceAggregatedUtterances() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "a data source listing the utterances of a v2 lex bot, aggregated over a time window",
+		ReadContext: dataSourceAggregatedUtterancesRead,
+		Schema: map[string]*schema.Schema{
+			"bot_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the bot",
+			},
+			"bot_alias_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "ID of the bot alias the utterances were sent to",
+				ExactlyOneOf: []string{"bot_alias_id", "bot_version"},
+			},
+			"bot_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Version of the bot the utterances were sent to",
+				ExactlyOneOf: []string{"bot_alias_id", "bot_version"},
+			},
+			"locale_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the locale, e.g. en_US",
+			},
+			"time_window": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "1w",
+				Description:      "Time window, up to now, to aggregate utterances over: `1h`, `3h`, `6h`, `12h`, `24h`, `3d`, `1w` or `2w`",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(aws_client.AggregationDurations(), false)),
+			},
+			"utterance_contains": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list utterances containing this text",
+			},
+			"missed_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only list utterances the bot did not recognize at least once",
+			},
+			"output_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of a csv file the utterances are exported to",
+			},
+			"utterances": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The utterances, most missed first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"utterance": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Text of the utterance",
+						},
+						"hit_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of times the utterance was recognized",
+						},
+						"missed_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of times the utterance was not recognized",
+						},
+						"first_recorded": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time (RFC3339) the utterance was first recorded in the time window",
+						},
+						"last_recorded": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time (RFC3339) the utterance was last recorded in the time window",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAggregatedUtterancesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	filter := aws_client.LexUtteranceFilter{
+		BotId:      d.Get("bot_id").(string),
+		BotAliasId: d.Get("bot_alias_id").(string),
+		BotVersion: d.Get("bot_version").(string),
+		LocaleId:   d.Get("locale_id").(string),
+		Duration:   d.Get("time_window").(string),
+		Contains:   d.Get("utterance_contains").(string),
+		MissedOnly: d.Get("missed_only").(bool),
+	}
+	outputPath := d.Get("output_path").(string)
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	utterances, err := awsClient.ListAggregatedUtterances(ctx, filter)
+
+	if err == nil && outputPath != "" {
+		err = aws_client.WriteUtterancesCsv(outputPath, utterances)
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to list aggregated utterances",
+			Detail:   fmt.Sprintf("Unable to list aggregated utterances, err: %s", err),
+		})
+		return diags
+	}
+
+	result := []interface{}{}
+
+	for _, utterance := range utterances {
+		result = append(result, map[string]interface{}{
+			"utterance":      utterance.Utterance,
+			"hit_count":      utterance.HitCount,
+			"missed_count":   utterance.MissedCount,
+			"first_recorded": utterance.FirstRecorded.Format(time.RFC3339),
+			"last_recorded":  utterance.LastRecorded.Format(time.RFC3339),
+		})
+	}
+
+	target := filter.BotAliasId
+	if target == "" {
+		target = filter.BotVersion
+	}
+
+	d.SetId(strings.Join([]string{filter.BotId, target, filter.LocaleId, filter.Duration}, "/"))
+	d.Set("utterances", result)
+
+	return diags
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAggregatedUtterances(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAggregatedUtterances,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.awslex_aggregated_utterances.foo", "id", "C5H22UIPWC/DRAFT/en_US/2w"),
+					resource.TestCheckResourceAttrSet(
+						"data.awslex_aggregated_utterances.foo", "utterances.#"),
+				),
+			},
+		},
+	})
+}
+
+// use the id of the stable dev bot
+const testAccDataSourceAggregatedUtterances = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_aggregated_utterances" "foo" {
+  bot_id      = "C5H22UIPWC"
+  bot_version = "DRAFT"
+  locale_id   = "en_US"
+  time_window = "2w"
+  missed_only = true
+}
+`
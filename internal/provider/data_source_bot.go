@@ -16,9 +16,11 @@ func dataSourceBot() *schema.Resource {
 		ReadContext: dataSourceBotRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "ID of the bot",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "ID of the bot",
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"alias": {
 				Type:             schema.TypeString,
@@ -37,9 +39,11 @@ func dataSourceBot() *schema.Resource {
 				Description: "Version of the bot",
 			},
 			"name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Name of bot",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Name of bot. Looks up the bot by name when `id` is not set",
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"lambda_arn": {
 				Type:        schema.TypeString,
@@ -61,10 +65,26 @@ func dataSourceBot() *schema.Resource {
 				Computed:    true,
 				Description: "Base64-encoded representation of raw SHA-256 sum of the zip file",
 			},
+			"bot_arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Arn of the bot",
+			},
+			"alias_arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Arn of the bot alias",
+			},
 			"tags": {
-				Type:     schema.TypeMap,
-				Computed: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Tags of the bot",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"alias_tags_match": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the alias has the same tags as the bot",
 			},
 		},
 	}
@@ -79,7 +99,29 @@ func dataSourceBotRead(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	awsClient := meta.(*aws_client.AwsClient)
 
-	bot, err := awsClient.GetBot(botId, botAlias)
+	// the id changes when the bot is recreated, the name does not
+	if botId == "" {
+
+		name := d.Get("name").(string)
+
+		var err error
+		botId, err = awsClient.FindBotByName(ctx, name)
+
+		if err == nil && botId == "" {
+			err = fmt.Errorf("no bot named %s", name)
+		}
+
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to find requested bot",
+				Detail:   fmt.Sprintf("Unable to find requested bot, err: %s", err),
+			})
+			return diags
+		}
+	}
+
+	bot, err := awsClient.GetBot(ctx, botId, botAlias)
 
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -98,7 +140,12 @@ func dataSourceBotRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	d.Set("description", bot.Description)
 	d.Set("version", bot.Version)
 	d.Set("alias_id", bot.AliasId)
+	d.Set("bot_arn", awsClient.BotArn(botId))
+	if bot.AliasId != "" {
+		d.Set("alias_arn", awsClient.AliasArn(botId, bot.AliasId))
+	}
 	d.Set("source_code_hash", bot.SourceCodeHash)
-	d.Set("tags", bot.Tags)
+	d.Set("tags", awsClient.RemoveIgnoredTags(bot.Tags))
+	d.Set("alias_tags_match", bot.AliasTagsMatch)
 	return diags
 }
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scg/va/aws_client"
+)
+
+func dataSourceBotAliases() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "a data source listing the aliases of a v2 lex bot",
+		ReadContext: dataSourceBotAliasesRead,
+		Schema: map[string]*schema.Schema{
+			"bot_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the bot",
+			},
+			"aliases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Aliases of the bot",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the alias",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the alias",
+						},
+						"arn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Arn of the alias",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the bot the alias points to",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the alias",
+						},
+						"lambda_arns": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Arn of the lambda of each locale, keyed by locale id",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Tags of the alias",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBotAliasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	botId := d.Get("bot_id").(string)
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	aliases, err := awsClient.ListBotAliases(ctx, botId)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to list bot aliases",
+			Detail:   fmt.Sprintf("Unable to list bot aliases, err: %s", err),
+		})
+		return diags
+	}
+
+	result := []interface{}{}
+
+	for _, alias := range aliases {
+		result = append(result, map[string]interface{}{
+			"id":          alias.Id,
+			"name":        alias.Name,
+			"arn":         awsClient.AliasArn(botId, alias.Id),
+			"version":     alias.Version,
+			"status":      alias.Status,
+			"lambda_arns": alias.LambdaArns,
+			"tags":        alias.Tags,
+		})
+	}
+
+	d.SetId(botId)
+	d.Set("aliases", result)
+
+	return diags
+}
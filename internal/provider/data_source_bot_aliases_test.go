@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBotAliases(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBotAliases,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.awslex_bot_aliases.foo", "aliases.*", map[string]string{"name": "latest"}),
+				),
+			},
+		},
+	})
+}
+
+// use the id of the stable dev bot
+const testAccDataSourceBotAliases = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_aliases" "foo" {
+  bot_id = "C5H22UIPWC"
+}
+`
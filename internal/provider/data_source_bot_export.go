@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scg/va/aws_client"
+)
+
+func dataSourceBotExport() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "a data source exporting a version of a v2 lex bot to a local archive",
+		ReadContext: dataSourceBotExportRead,
+		Schema: map[string]*schema.Schema{
+			"bot_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the bot",
+			},
+			"bot_version": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Version of the bot to export, e.g. 3 or DRAFT",
+			},
+			"locale_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only export this locale, e.g. en_US",
+			},
+			"output_path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path the zip archive is downloaded to",
+			},
+			"unpack_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Directory the archive is unpacked into, in the lex import/export layout",
+			},
+			"output_base64sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base64-encoded representation of raw SHA-256 sum of the zip file",
+			},
+		},
+	}
+}
+
+func dataSourceBotExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	export := aws_client.LexBotExport{
+		BotId:      d.Get("bot_id").(string),
+		BotVersion: d.Get("bot_version").(string),
+		LocaleId:   d.Get("locale_id").(string),
+	}
+	outputPath := d.Get("output_path").(string)
+	unpackDir := d.Get("unpack_dir").(string)
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	err := awsClient.ExportBot(ctx, export, outputPath)
+
+	if err == nil && unpackDir != "" {
+		err = aws_client.Unzip(outputPath, unpackDir)
+	}
+
+	var hash string
+
+	if err == nil {
+		hash, err = aws_client.ArchiveHash(outputPath)
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to export requested bot",
+			Detail:   fmt.Sprintf("Unable to export requested bot, err: %s", err),
+		})
+		return diags
+	}
+
+	id := []string{export.BotId, export.BotVersion}
+	if export.LocaleId != "" {
+		id = append(id, export.LocaleId)
+	}
+
+	d.SetId(strings.Join(id, "/"))
+	d.Set("output_base64sha256", hash)
+
+	return diags
+}
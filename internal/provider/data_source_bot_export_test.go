@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBotExport(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBotExport,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.awslex_bot_export.foo", "output_base64sha256", regexp.MustCompile("^[A-Za-z0-9+/]{43}=$")),
+				),
+			},
+		},
+	})
+}
+
+// export the draft of the stable dev bot
+const testAccDataSourceBotExport = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_export" "foo" {
+  bot_id      = "C5H22UIPWC"
+  bot_version = "DRAFT"
+  output_path = "${path.module}/export/bot.zip"
+}
+`
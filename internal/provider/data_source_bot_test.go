@@ -36,3 +36,47 @@ data "awslex_bot_resource" "foo" {
   alias = "latest"
 }
 `
+
+func TestAccDataSourceBotByName(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBotByName,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.awslex_bot_resource.foo", "id", "C5H22UIPWC"),
+				),
+			},
+			{
+				Config:      testAccDataSourceBotByMissingName,
+				ExpectError: regexp.MustCompile("no bot named"),
+			},
+		},
+	})
+}
+
+// use the name of the bot created by the bot resource example
+const testAccDataSourceBotByName = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_resource" "foo" {
+  name  = "TerraBot"
+  alias = "latest"
+}
+`
+
+const testAccDataSourceBotByMissingName = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_resource" "foo" {
+  name  = "TerraBot-missing"
+  alias = "latest"
+}
+`
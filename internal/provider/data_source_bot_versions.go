@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scg/va/aws_client"
+)
+
+func dataSourceBotVersions() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "a data source listing the versions of a v2 lex bot",
+		ReadContext: dataSourceBotVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"bot_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the bot",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Versions of the bot, including the draft version",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the bot",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the version",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time (RFC3339) the version was created",
+						},
+						"source_code_hash": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Base64-encoded representation of raw SHA-256 sum of the zip file the version was built from",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBotVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	botId := d.Get("bot_id").(string)
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	versions, err := awsClient.ListBotVersions(ctx, botId)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to list bot versions",
+			Detail:   fmt.Sprintf("Unable to list bot versions, err: %s", err),
+		})
+		return diags
+	}
+
+	result := []interface{}{}
+
+	for _, version := range versions {
+		result = append(result, map[string]interface{}{
+			"version":          version.Version,
+			"status":           version.Status,
+			"created":          version.Created.Format(time.RFC3339),
+			"source_code_hash": version.SourceCodeHash,
+		})
+	}
+
+	d.SetId(botId)
+	d.Set("versions", result)
+
+	return diags
+}
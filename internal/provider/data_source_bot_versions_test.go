@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBotVersions(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBotVersions,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.awslex_bot_versions.foo", "versions.*", map[string]string{"version": "DRAFT"}),
+				),
+			},
+		},
+	})
+}
+
+// use the id of the stable dev bot
+const testAccDataSourceBotVersions = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_versions" "foo" {
+  bot_id = "C5H22UIPWC"
+}
+`
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scg/va/aws_client"
+)
+
+func dataSourceBots() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "a data source listing the v2 lex bots of the account",
+		ReadContext: dataSourceBotsRead,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list bots whose name starts with this prefix",
+			},
+			"status": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only list bots with this status, e.g. Available or Failed",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(aws_client.BotStatuses(), false)),
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Only list bots with all of these tags",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the bots",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the bots",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"bots": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The bots",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the bot",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the bot",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the bot",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the bot",
+						},
+						"last_updated": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time (RFC3339) the bot was last updated",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBotsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	bots, err := awsClient.ListBots(ctx, aws_client.LexBotFilter{
+		NamePrefix: d.Get("name_prefix").(string),
+		Status:     d.Get("status").(string),
+		Tags:       convertTags(d.Get("tags").(map[string]interface{})),
+	})
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to list bots",
+			Detail:   fmt.Sprintf("Unable to list bots, err: %s", err),
+		})
+		return diags
+	}
+
+	ids := []string{}
+	names := []string{}
+	result := []interface{}{}
+
+	for _, bot := range bots {
+		ids = append(ids, bot.Id)
+		names = append(names, bot.Name)
+		result = append(result, map[string]interface{}{
+			"id":           bot.Id,
+			"name":         bot.Name,
+			"description":  bot.Description,
+			"status":       bot.Status,
+			"last_updated": bot.LastUpdated.Format(time.RFC3339),
+		})
+	}
+
+	d.SetId(awsClient.Region)
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("bots", result)
+
+	return diags
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBots(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBots,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(
+						"data.awslex_bots.available", "ids.*", "C5H22UIPWC"),
+				),
+			},
+		},
+	})
+}
+
+// the stable dev bot is available
+const testAccDataSourceBots = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bots" "available" {
+  status = "Available"
+}
+`
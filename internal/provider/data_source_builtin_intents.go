@@ -0,0 +1,15 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scg/va/aws_client"
+)
+
+func dataSourceBuiltInIntents() *schema.Resource {
+
+	return dataSourceBuiltIns(builtInKind{
+		name:    "intents",
+		example: "AMAZON.FallbackIntent",
+		list:    (*aws_client.AwsClient).ListBuiltInIntents,
+	})
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBuiltInIntents(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBuiltInIntents,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(
+						"data.awslex_builtin_intents.foo", "signatures.*", "AMAZON.FallbackIntent"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceBuiltInIntents = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_builtin_intents" "foo" {
+  locale_id = "es_US"
+}
+`
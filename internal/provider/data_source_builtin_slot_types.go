@@ -0,0 +1,15 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scg/va/aws_client"
+)
+
+func dataSourceBuiltInSlotTypes() *schema.Resource {
+
+	return dataSourceBuiltIns(builtInKind{
+		name:    "slot types",
+		example: "AMAZON.Number",
+		list:    (*aws_client.AwsClient).ListBuiltInSlotTypes,
+	})
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBuiltInSlotTypes(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBuiltInSlotTypes,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(
+						"data.awslex_builtin_slot_types.foo", "signatures.*", "AMAZON.Number"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceBuiltInSlotTypes = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_builtin_slot_types" "foo" {
+  locale_id = "es_US"
+}
+`
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scg/va/aws_client"
+)
+
+// a kind of lex built-in, listed by its own data source
+type builtInKind struct {
+	// plural, e.g. "slot types". the attribute listing them is named after it
+	name string
+	// signature of one of them, e.g. AMAZON.Number
+	example string
+	list    func(c *aws_client.AwsClient, ctx context.Context, localeId string) ([]aws_client.LexBuiltIn, error)
+}
+
+func (kind builtInKind) attribute() string {
+	return strings.ReplaceAll(kind.name, " ", "_")
+}
+
+// a data source listing the built-ins of a kind for a lex locale
+func dataSourceBuiltIns(kind builtInKind) *schema.Resource {
+
+	return &schema.Resource{
+		Description: fmt.Sprintf("a data source listing the built-in %s of a lex locale", kind.name),
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return dataSourceBuiltInsRead(ctx, d, meta, kind)
+		},
+		Schema: map[string]*schema.Schema{
+			"locale_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the locale, e.g. es_US",
+			},
+			"signatures": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: fmt.Sprintf("Signatures of the built-in %s, e.g. `%s`, usable as the parent of a derived one", kind.name, kind.example),
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			kind.attribute(): {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: fmt.Sprintf("The built-in %s", kind.name),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"signature": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Signature of the built-in",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the built-in",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuiltInsRead(ctx context.Context, d *schema.ResourceData, meta interface{}, kind builtInKind) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	localeId := d.Get("locale_id").(string)
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	builtIns, err := kind.list(awsClient, ctx, localeId)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to list built-in %s", kind.name),
+			Detail:   fmt.Sprintf("Unable to list built-in %s, err: %s", kind.name, err),
+		})
+		return diags
+	}
+
+	signatures := []string{}
+	result := []interface{}{}
+
+	for _, builtIn := range builtIns {
+		signatures = append(signatures, builtIn.Signature)
+		result = append(result, map[string]interface{}{
+			"signature":   builtIn.Signature,
+			"description": builtIn.Description,
+		})
+	}
+
+	d.SetId(localeId)
+	d.Set("signatures", signatures)
+	d.Set(kind.attribute(), result)
+
+	return diags
+}
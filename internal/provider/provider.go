@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scg/va/aws_client"
 )
 
@@ -24,15 +26,221 @@ func Provider(version string) *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("AWS_DEFAULT_REGION", nil),
 			},
 			"role_arn": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Deprecated:    "use assume_role instead",
+				ConflictsWith: []string{"assume_role"},
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWS_PROFILE", ""),
+				Description: "Profile from the shared config and credentials files",
+			},
+			"shared_config_files": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Paths of shared config files, instead of `~/.aws/config`",
+			},
+			"assume_role": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Role to assume, using the credentials from the profile or web identity",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_arn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Arn of the role to assume",
+						},
+						"session_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Session name to use when assuming the role",
+						},
+						"external_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "External id to use when assuming the role",
+						},
+						"duration": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Duration of the role session, e.g. `1h`. Between 15m and 12h",
+						},
+						"policy": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "IAM policy json further restricting the permissions of the session",
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Session tags",
+						},
+					},
+				},
+			},
+			"assume_role_with_web_identity": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Role to assume with a web identity (oidc) token, e.g. from a ci runner",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_arn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Arn of the role to assume",
+						},
+						"session_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Session name to use when assuming the role",
+						},
+						"web_identity_token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Web identity token",
+						},
+						"web_identity_token_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path of a file containing the web identity token",
+						},
+						"duration": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Duration of the role session, e.g. `1h`. Between 15m and 12h",
+						},
+					},
+				},
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Custom endpoints, e.g. to use a local stand-in for aws",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"lexmodelsv2": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Endpoint of the lex v2 models api",
+						},
+						"sts": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Endpoint of the sts api",
+						},
+						"lambda": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Endpoint of the lambda api",
+						},
+						"iam": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Endpoint of the iam api",
+						},
+						"upload": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Scheme and host replacing those of the pre-signed urls bot archives are uploaded to and exported from",
+						},
+					},
+				},
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip the sts lookup of the caller identity. Requires `account_id`",
+			},
+			"account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Account id of the bots. Looked up with sts when not set",
+			},
+			"use_fips_endpoint": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use fips endpoints of the aws services",
+			},
+			"max_retries": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          -1,
+				Description:      "Maximum number of times a failed aws api call is retried. `0` disables retries, `-1` keeps the sdk default",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(-1)),
+			},
+			"retry_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     aws_client.RetryModeStandard,
+				Description: "Retry mode: `standard` or `adaptive`, which also rate limits calls after throttling errors",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					aws_client.RetryModeStandard, aws_client.RetryModeAdaptive}, false)),
+			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags added to every resource of the provider. Resource tags take precedence",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"ignore_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags managed outside of terraform, never reported by the provider",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Tag keys to ignore",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Tag key prefixes to ignore",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"awslex_bot_resource": dataSourceBot(),
+			"awslex_bot_resource":          dataSourceBot(),
+			"awslex_bots":                  dataSourceBots(),
+			"awslex_bot_versions":          dataSourceBotVersions(),
+			"awslex_bot_aliases":           dataSourceBotAliases(),
+			"awslex_bot_export":            dataSourceBotExport(),
+			"awslex_builtin_intents":       dataSourceBuiltInIntents(),
+			"awslex_builtin_slot_types":    dataSourceBuiltInSlotTypes(),
+			"awslex_aggregated_utterances": dataSourceAggregatedUtterances(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"awslex_bot_resource": resourceBot(),
+			"awslex_bot_resource":       resourceBot(),
+			"awslex_resource_policy":    resourceResourcePolicy(),
+			"awslex_bot_promotion":      resourceBotPromotion(),
+			"awslex_bot_recommendation": resourceBotRecommendation(),
+			"awslex_qna_index":          resourceQnaIndex(),
 		},
 		ConfigureContextFunc: configure,
 	}
@@ -42,10 +250,22 @@ func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.D
 
 	var diags diag.Diagnostics
 
-	region := d.Get("region").(string)
-	role_arn := d.Get("role_arn").(string)
+	config, err := expandConfig(d)
 
-	askClient, err := aws_client.NewClient(region, role_arn)
+	if err == nil {
+		err = config.Validate()
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid provider configuration",
+			Detail:   fmt.Sprintf("Invalid provider configuration: %s", err),
+		})
+		return nil, diags
+	}
+
+	askClient, err := aws_client.NewClient(ctx, config)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -57,3 +277,106 @@ func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.D
 
 	return askClient, diags
 }
+
+func expandConfig(d *schema.ResourceData) (aws_client.Config, error) {
+
+	config := aws_client.Config{
+		Region:                    d.Get("region").(string),
+		Profile:                   d.Get("profile").(string),
+		SkipCredentialsValidation: d.Get("skip_credentials_validation").(bool),
+		AccountId:                 d.Get("account_id").(string),
+		UseFIPSEndpoint:           d.Get("use_fips_endpoint").(bool),
+		RetryMode:                 d.Get("retry_mode").(string),
+	}
+
+	// -1 keeps the sdk default
+	if maxRetries := d.Get("max_retries").(int); maxRetries >= 0 {
+		config.MaxRetries = &maxRetries
+	}
+
+	if l := d.Get("endpoints").([]interface{}); len(l) > 0 && l[0] != nil {
+		m := l[0].(map[string]interface{})
+
+		config.Endpoints = aws_client.Endpoints{
+			LexModelsV2: m["lexmodelsv2"].(string),
+			Sts:         m["sts"].(string),
+			Lambda:      m["lambda"].(string),
+			Iam:         m["iam"].(string),
+			Upload:      m["upload"].(string),
+		}
+	}
+
+	if l := d.Get("default_tags").([]interface{}); len(l) > 0 && l[0] != nil {
+		m := l[0].(map[string]interface{})
+		config.DefaultTags = convertTags(m["tags"].(map[string]interface{}))
+	}
+
+	if l := d.Get("ignore_tags").([]interface{}); len(l) > 0 && l[0] != nil {
+		m := l[0].(map[string]interface{})
+		for _, key := range m["keys"].(*schema.Set).List() {
+			config.IgnoreTags.Keys = append(config.IgnoreTags.Keys, key.(string))
+		}
+		for _, prefix := range m["key_prefixes"].(*schema.Set).List() {
+			config.IgnoreTags.KeyPrefixes = append(config.IgnoreTags.KeyPrefixes, prefix.(string))
+		}
+	}
+
+	for _, file := range d.Get("shared_config_files").([]interface{}) {
+		config.SharedConfigFiles = append(config.SharedConfigFiles, file.(string))
+	}
+
+	// the deprecated role_arn is shorthand for an assume_role block
+	if roleArn := d.Get("role_arn").(string); roleArn != "" {
+		config.AssumeRole = &aws_client.AssumeRole{RoleArn: roleArn}
+	}
+
+	if l := d.Get("assume_role").([]interface{}); len(l) > 0 && l[0] != nil {
+		m := l[0].(map[string]interface{})
+
+		duration, err := parseDuration(m["duration"].(string))
+		if err != nil {
+			return config, fmt.Errorf("invalid assume_role: %s", err)
+		}
+
+		config.AssumeRole = &aws_client.AssumeRole{
+			RoleArn:     m["role_arn"].(string),
+			SessionName: m["session_name"].(string),
+			ExternalId:  m["external_id"].(string),
+			Duration:    duration,
+			Policy:      m["policy"].(string),
+			Tags:        convertTags(m["tags"].(map[string]interface{})),
+		}
+	}
+
+	if l := d.Get("assume_role_with_web_identity").([]interface{}); len(l) > 0 && l[0] != nil {
+		m := l[0].(map[string]interface{})
+
+		duration, err := parseDuration(m["duration"].(string))
+		if err != nil {
+			return config, fmt.Errorf("invalid assume_role_with_web_identity: %s", err)
+		}
+
+		config.AssumeRoleWithWebIdentity = &aws_client.AssumeRoleWithWebIdentity{
+			RoleArn:              m["role_arn"].(string),
+			SessionName:          m["session_name"].(string),
+			WebIdentityToken:     m["web_identity_token"].(string),
+			WebIdentityTokenFile: m["web_identity_token_file"].(string),
+			Duration:             duration,
+		}
+	}
+
+	return config, nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("duration %q is not a valid duration, e.g. 1h or 90m", s)
+	}
+
+	return duration, nil
+}
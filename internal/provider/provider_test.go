@@ -1,9 +1,12 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scg/va/aws_client"
 )
 
 // providerFactories are used to instantiate a provider during acceptance testing.
@@ -19,6 +22,46 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+// configure must not need network access to aws when pointed at a local stand-in
+func TestProviderConfigureOffline(t *testing.T) {
+	p := Provider("dev")
+
+	diags := p.Configure(context.TODO(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region":                      "us-west-2",
+		"account_id":                  "000000000000",
+		"skip_credentials_validation": true,
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"lexmodelsv2": "http://localhost:4566",
+				"sts":         "http://localhost:4566",
+				"upload":      "http://localhost:4566",
+			},
+		},
+		"default_tags": []interface{}{
+			map[string]interface{}{
+				"tags": map[string]interface{}{"unit": "shcva"},
+			},
+		},
+		"ignore_tags": []interface{}{
+			map[string]interface{}{
+				"key_prefixes": []interface{}{"org:"},
+			},
+		},
+	}))
+
+	if diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+
+	awsClient := p.Meta().(*aws_client.AwsClient)
+	if awsClient.AccountId != "000000000000" || awsClient.Endpoints.Upload != "http://localhost:4566" {
+		t.Errorf("unexpected client: %+v", awsClient)
+	}
+	if awsClient.DefaultTags["unit"] != "shcva" || len(awsClient.IgnoreTags.KeyPrefixes) != 1 {
+		t.Errorf("unexpected tag settings: %+v", awsClient)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
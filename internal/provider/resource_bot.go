@@ -3,11 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"log"
+	"os"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scg/va/aws_client"
 )
 
@@ -19,6 +23,7 @@ func resourceBot() *schema.Resource {
 		ReadContext:   resourceBotRead,
 		UpdateContext: resourceBotUpdate,
 		DeleteContext: resourceBotDelete,
+		CustomizeDiff: resourceBotCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -48,24 +53,86 @@ func resourceBot() *schema.Resource {
 				Description: "ID of the bot alias",
 			},
 			"archive_path": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Path to the zip archive containing intents and slots",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Path to the zip archive containing intents and slots. Optional with `qna` blocks or a `qna_file`",
+				AtLeastOneOf: []string{"archive_path", "qna", "qna_file"},
 			},
 			"source_code_hash": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Base64-encoded representation of the SHA-256 sum of the zip file. Computed from the generated archive with `qna` blocks or a `qna_file`",
+				ConflictsWith: []string{"qna", "qna_file"},
+			},
+			"qna": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Question and answer pair. The QnaIntent, its qnaslot and the QnaSlotType of each locale are generated into the archive",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the pair, which the answers are keyed by",
+						},
+						"locale": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     aws_client.DefaultLocale,
+							Description: "ID of the locale of the pair, e.g. en_US",
+						},
+						"questions": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Questions, added as values of the QnaSlotType",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"answer": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Answer returned by the fulfillment lambda",
+						},
+					},
+				},
+			},
+			"qna_file": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Base64-encoded representation of the SHA-256 sum of the zip file",
+				Optional:    true,
+				Description: "Path to a csv file with an `id`, `locale`, `question` and `answer` header line, or a yaml list of rows with the same keys. Rows of the same id and locale make up one pair, in addition to the `qna` blocks",
+			},
+			"qna_answers": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Answers and questions of the `qna` blocks and the `qna_file` as json, keyed by id and then locale, for the fulfillment lambda",
+			},
+			"qna_answers_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of a file `qna_answers` is written to on create and update",
 			},
 			"lambda_arn": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "Arn of router lambda",
 			},
+			"manage_lambda_permission": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Add (and remove) the permission for the bot alias to invoke the router lambda",
+			},
+			"lambda_permission_present": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the bot alias is allowed to invoke the router lambda. Planned as `true` when the permission is managed but missing, so the next apply adds it again",
+			},
 			"iam_role": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Arn of IAM role to use with the bot",
+				Optional:    true,
+				Computed:    true,
+				Description: "Arn of IAM role to use with the bot. Defaults to a lex service-linked role for the bot (`AWSServiceRoleForLexV2Bots_<name>`), created if needed",
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -73,9 +140,105 @@ func resourceBot() *schema.Resource {
 				Description: "Description of bot",
 			},
 			"tags": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Tags of the bot and its alias",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"alias_tags_match": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the alias has the same tags as the bot. Planned as `true` when they differ, so the next apply sets the tags on the alias again",
+			},
+			"bot_arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Arn of the bot",
+			},
+			"alias_arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Arn of the bot alias",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "On create, continue with an existing bot of the same name (using the same role) instead of failing",
+			},
+			"completed_steps": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Create steps completed so far. A create that failed part way through resumes from the first step not listed",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Tags of the bot, including the provider default tags",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"locale": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Overrides applied to a bot locale after the archive is imported",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"locale_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the locale, e.g. en_US",
+						},
+						"nlu_confidence_threshold": {
+							Type:             schema.TypeFloat,
+							Optional:         true,
+							Description:      "Intent confidence threshold, between 0 and 1, below which the fallback intent is used",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(0, 1)),
+						},
+						"voice_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Amazon Polly voice used in voice conversations",
+						},
+						"voice_engine": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Amazon Polly engine used by the voice: `standard` or `neural`",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								aws_client.VoiceEngineStandard, aws_client.VoiceEngineNeural}, false)),
+						},
+						"custom_vocabulary": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Phrases, e.g. product names, that speech recognition should favor in voice conversations",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"phrase": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The phrase",
+									},
+									"weight": {
+										Type:             schema.TypeInt,
+										Optional:         true,
+										Description:      "Weight of the phrase, between 0 (no weight) and 3",
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, aws_client.MaxVocabularyWeight)),
+									},
+								},
+							},
+						},
+						"custom_vocabulary_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path to a tab separated file of custom vocabulary phrases and optional weights, with a `phrase` and `weight` header line. Instead of `custom_vocabulary`",
+						},
+						"custom_vocabulary_hash": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Used to trigger a new import when `custom_vocabulary_file` changes, e.g. `filebase64sha256(\"vocabulary.tsv\")`",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -86,6 +249,8 @@ func resourceBotCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	var diags diag.Diagnostics
 	var bot aws_client.LexBot
 
+	awsClient := meta.(*aws_client.AwsClient)
+
 	bot.Name = d.Get("name").(string)
 	bot.Alias = d.Get("alias").(string)
 	bot.LambdaArn = d.Get("lambda_arn").(string)
@@ -93,11 +258,30 @@ func resourceBotCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	bot.IamRoleArn = d.Get("iam_role").(string)
 	bot.ArchivePath = d.Get("archive_path").(string)
 	bot.SourceCodeHash = d.Get("source_code_hash").(string)
-	bot.Tags = convertTags(d.Get("tags").(map[string]interface{}))
+	bot.Tags = awsClient.MergeDefaultTags(convertTags(d.Get("tags").(map[string]interface{})))
+	bot.Locales = expandLocales(d.Get("locale").([]interface{}))
+	bot.ManageLambdaPermission = d.Get("manage_lambda_permission").(bool)
+	bot.AdoptExisting = d.Get("adopt_existing").(bool)
 
-	awsClient := meta.(*aws_client.AwsClient)
+	qna, err := loadQna(d.Get("qna").([]interface{}), d.Get("qna_file").(string))
+	bot.Qna = qna
 
-	err := awsClient.CreateBot(&bot)
+	if err == nil {
+		err = prepareQna(d, &bot)
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to generate qna archive",
+			Detail:   fmt.Sprintf("Unable to generate qna archive, err: %s", err),
+		})
+		return diags
+	}
+
+	diags = append(diags, qnaTranslationWarnings(bot.Qna)...)
+
+	err = awsClient.CreateBot(ctx, &bot)
 
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -105,17 +289,45 @@ func resourceBotCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 			Summary:  "Unable to create configured bot",
 			Detail:   fmt.Sprintf("Unable to create configured bot, err: %s", err),
 		})
+	}
+
+	// the bot may exist even if a later step failed. keep it in state along
+	// with the completed steps, so it is not orphaned and can be resumed
+	if bot.Id == "" {
 		return diags
 	}
 
 	// set computed values
 	d.SetId(bot.Id)
+	d.Set("completed_steps", bot.CompletedSteps)
 	d.Set("version", bot.Version)
 	d.Set("alias_id", bot.AliasId)
+	d.Set("iam_role", bot.IamRoleArn)
+	d.Set("bot_arn", awsClient.BotArn(bot.Id))
+	if bot.AliasId != "" {
+		d.Set("alias_arn", awsClient.AliasArn(bot.Id, bot.AliasId))
+	}
+	d.Set("tags_all", awsClient.RemoveIgnoredTags(bot.Tags))
+	d.Set("alias_tags_match", true)
+	d.Set("lambda_permission_present", bot.StepCompleted(aws_client.StepLambdaPermission) && bot.ManageLambdaPermission)
 
 	return diags
 }
 
+func expandCompletedSteps(steps []interface{}) []string {
+	result := []string{}
+	for _, step := range steps {
+		result = append(result, step.(string))
+	}
+	return result
+}
+
+// bots created before steps were recorded have none, and are complete
+func createCompleted(awsClient *aws_client.AwsClient, steps []interface{}) bool {
+	bot := aws_client.LexBot{CompletedSteps: expandCompletedSteps(steps)}
+	return len(bot.CompletedSteps) == 0 || awsClient.CreateCompleted(&bot)
+}
+
 func convertTags(tags map[string]interface{}) map[string]string {
 	result := map[string]string{}
 	for k := range tags {
@@ -126,9 +338,363 @@ func convertTags(tags map[string]interface{}) map[string]string {
 	return result
 }
 
+func expandLocales(locales []interface{}) []aws_client.LexBotLocale {
+	result := []aws_client.LexBotLocale{}
+	for _, l := range locales {
+		locale, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, aws_client.LexBotLocale{
+			LocaleId:               locale["locale_id"].(string),
+			NluConfidenceThreshold: locale["nlu_confidence_threshold"].(float64),
+			VoiceId:                locale["voice_id"].(string),
+			VoiceEngine:            locale["voice_engine"].(string),
+			CustomVocabulary:       expandVocabulary(locale["custom_vocabulary"].([]interface{})),
+			CustomVocabularyFile:   locale["custom_vocabulary_file"].(string),
+		})
+	}
+	return result
+}
+
+func expandQna(pairs []interface{}) []aws_client.LexQna {
+	result := []aws_client.LexQna{}
+	for _, p := range pairs {
+		qna, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		questions := []string{}
+		for _, question := range qna["questions"].([]interface{}) {
+			if q, ok := question.(string); ok {
+				questions = append(questions, q)
+			}
+		}
+		result = append(result, aws_client.LexQna{
+			Id:        qna["id"].(string),
+			LocaleId:  qna["locale"].(string),
+			Questions: questions,
+			Answer:    qna["answer"].(string),
+		})
+	}
+	return result
+}
+
+// the qna blocks followed by the pairs of the qna file, if any
+func loadQna(blocks []interface{}, qnaFile string) ([]aws_client.LexQna, error) {
+
+	pairs := expandQna(blocks)
+
+	if qnaFile != "" {
+		filePairs, err := aws_client.ReadQnaFile(qnaFile)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, filePairs...)
+	}
+
+	if err := aws_client.ValidateQna(pairs); err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+// warn about qna ids that some of the locales have no pair for
+func qnaTranslationWarnings(pairs []aws_client.LexQna) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	missing := aws_client.MissingQnaTranslations(pairs)
+
+	if len(missing) == 0 {
+		return diags
+	}
+
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Missing qna translations",
+		Detail:   fmt.Sprintf("The bot cannot answer these qna ids in the listed locales: %s", strings.Join(missing, "; ")),
+	})
+
+	return diags
+}
+
+// with qna pairs, the source code hash is that of the generated archive. The
+// answers are set and written to qna_answers_path, if configured
+func prepareQna(d *schema.ResourceData, bot *aws_client.LexBot) error {
+
+	if len(bot.Qna) == 0 {
+		d.Set("qna_answers", "")
+		return nil
+	}
+
+	hash, err := aws_client.QnaArchiveHash(*bot)
+	if err != nil {
+		return err
+	}
+	bot.SourceCodeHash = hash
+
+	answers, err := aws_client.QnaAnswers(bot.Qna)
+	if err != nil {
+		return err
+	}
+
+	d.Set("source_code_hash", bot.SourceCodeHash)
+	d.Set("qna_answers", answers)
+
+	if answersPath := d.Get("qna_answers_path").(string); answersPath != "" {
+		return aws_client.WriteQnaAnswers(answersPath, bot.Qna)
+	}
+
+	return nil
+}
+
+func expandVocabulary(items []interface{}) []aws_client.LexVocabularyItem {
+	result := []aws_client.LexVocabularyItem{}
+	for _, i := range items {
+		item, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, aws_client.LexVocabularyItem{
+			Phrase: item["phrase"].(string),
+			Weight: item["weight"].(int),
+		})
+	}
+	return result
+}
+
+// validate locale overrides and compute tags_all at plan time
+func resourceBotCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	if !d.NewValueKnown("tags") {
+		if err := d.SetNewComputed("tags_all"); err != nil {
+			return err
+		}
+	} else {
+		tagsAll := awsClient.MergeDefaultTags(convertTags(d.Get("tags").(map[string]interface{})))
+		if err := d.SetNew("tags_all", awsClient.RemoveIgnoredTags(tagsAll)); err != nil {
+			return err
+		}
+	}
+
+	// the permission changes along with the lambda and alias it is for. a
+	// managed permission removed outside of terraform is added again
+	if d.Id() != "" {
+		if d.HasChange("manage_lambda_permission") || d.HasChange("lambda_arn") || d.HasChange("alias") {
+			if err := d.SetNewComputed("lambda_permission_present"); err != nil {
+				return err
+			}
+		} else if d.Get("manage_lambda_permission").(bool) && !d.Get("lambda_permission_present").(bool) {
+			if err := d.SetNew("lambda_permission_present", true); err != nil {
+				return err
+			}
+		}
+	}
+
+	// make sure tags that differ between the bot and its alias are set again
+	if d.Id() != "" && !d.Get("alias_tags_match").(bool) {
+		if err := d.SetNew("alias_tags_match", true); err != nil {
+			return err
+		}
+	}
+
+	// make sure a create that failed part way through is resumed
+	if d.Id() != "" && !createCompleted(awsClient, d.Get("completed_steps").([]interface{})) {
+		if err := d.SetNewComputed("completed_steps"); err != nil {
+			return err
+		}
+	}
+
+	if err := qnaCustomizeDiff(d); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+
+	for _, locale := range expandLocales(d.Get("locale").([]interface{})) {
+
+		if seen[locale.LocaleId] {
+			return fmt.Errorf("locale %s is configured more than once", locale.LocaleId)
+		}
+		seen[locale.LocaleId] = true
+
+		if locale.VoiceId != "" {
+			if err := aws_client.ValidateVoice(locale.LocaleId, locale.VoiceId, locale.VoiceEngine); err != nil {
+				return fmt.Errorf("invalid voice settings for locale %s: %s", locale.LocaleId, err)
+			}
+		}
+
+		if len(locale.CustomVocabulary) > 0 && locale.CustomVocabularyFile != "" {
+			return fmt.Errorf("locale %s sets both custom_vocabulary and custom_vocabulary_file", locale.LocaleId)
+		}
+
+		if err := aws_client.ValidateVocabulary(locale.CustomVocabulary); err != nil {
+			return fmt.Errorf("invalid custom vocabulary for locale %s: %s", locale.LocaleId, err)
+		}
+	}
+
+	return nil
+}
+
+// validate the qna pairs and plan the source code hash of the archive
+// generated for them, along with the answers
+func qnaCustomizeDiff(d *schema.ResourceDiff) error {
+
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+
+	blocks := config.GetAttr("qna")
+	qnaFile := config.GetAttr("qna_file")
+
+	if blocks.IsKnown() && (blocks.IsNull() || blocks.LengthInt() == 0) && qnaFile.IsNull() {
+		if config.GetAttr("source_code_hash").IsNull() {
+			return fmt.Errorf("source_code_hash is required unless qna blocks or a qna_file are configured")
+		}
+		return d.SetNew("qna_answers", "")
+	}
+
+	// the qna file may only be written during the apply
+	fileMissing := false
+	if path := d.Get("qna_file").(string); path != "" {
+		_, err := os.Stat(path)
+		fileMissing = os.IsNotExist(err)
+	}
+
+	if !blocks.IsWhollyKnown() || !qnaFile.IsKnown() || fileMissing {
+		if err := d.SetNewComputed("qna_answers"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("source_code_hash")
+	}
+
+	pairs, err := loadQna(d.Get("qna").([]interface{}), d.Get("qna_file").(string))
+	if err != nil {
+		return fmt.Errorf("invalid qna: %s", err)
+	}
+
+	answers, err := aws_client.QnaAnswers(pairs)
+	if err != nil {
+		return err
+	}
+
+	if err = d.SetNew("qna_answers", answers); err != nil {
+		return err
+	}
+
+	bot := aws_client.LexBot{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		ArchivePath: d.Get("archive_path").(string),
+		Qna:         pairs,
+		Locales:     expandLocales(d.Get("locale").([]interface{})),
+	}
+
+	// the archive may only be created during the apply
+	hash, err := aws_client.QnaArchiveHash(bot)
+
+	if err != nil || !d.NewValueKnown("name") || !d.NewValueKnown("description") || !d.NewValueKnown("archive_path") {
+		return d.SetNewComputed("source_code_hash")
+	}
+
+	// every locale with pairs or a locale block is built
+	if d.NewValueKnown("locale") {
+		if err = aws_client.ValidateQnaLocales(bot); err != nil {
+			return fmt.Errorf("invalid qna: %s", err)
+		}
+	}
+
+	return d.SetNew("source_code_hash", hash)
+}
+
 func resourceBotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	return dataSourceBotRead(ctx, d, meta)
+	awsClient := meta.(*aws_client.AwsClient)
+
+	// tags configured on the resource, before being replaced by the aws tags
+	resourceTags := convertTags(d.Get("tags").(map[string]interface{}))
+
+	// values recorded by a partial create, which has no alias to read them from
+	version := d.Get("version").(string)
+	aliasId := d.Get("alias_id").(string)
+
+	diags := dataSourceBotRead(ctx, d, meta)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if !createCompleted(awsClient, d.Get("completed_steps").([]interface{})) {
+		d.Set("version", version)
+		d.Set("alias_id", aliasId)
+		return diags
+	}
+
+	// the data source reports all tags, the resource only those it configures
+	tagsAll := convertTags(d.Get("tags").(map[string]interface{}))
+	d.Set("tags_all", tagsAll)
+	d.Set("tags", awsClient.RemoveDefaultTags(tagsAll, resourceTags))
+
+	// a plan refreshes state, so the warnings show up before the apply
+	if qna, err := loadQna(d.Get("qna").([]interface{}), d.Get("qna_file").(string)); err == nil {
+		diags = append(diags, qnaTranslationWarnings(qna)...)
+	}
+
+	return append(diags, checkLambdaPermission(ctx, d, meta)...)
+}
+
+// warn (at plan time, since plans refresh state) when the alias is not allowed
+// to invoke its lambda
+func checkLambdaPermission(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	bot := aws_client.LexBot{
+		Id:        d.Id(),
+		AliasId:   d.Get("alias_id").(string),
+		LambdaArn: d.Get("lambda_arn").(string),
+	}
+
+	if bot.AliasId == "" || bot.LambdaArn == "" {
+		return diags
+	}
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	found, err := awsClient.HasLambdaPermission(ctx, &bot)
+
+	if err != nil {
+		log.Printf("[DEBUG] unable to check invoke permission of lambda %s: %s\n", bot.LambdaArn, err)
+		return diags
+	}
+
+	d.Set("lambda_permission_present", found)
+
+	if found {
+		return diags
+	}
+
+	detail := fmt.Sprintf("Lex is not allowed to invoke %s from alias %s, so the bot will fail with an access denied error.", bot.LambdaArn, bot.AliasId)
+
+	if d.Get("manage_lambda_permission").(bool) {
+		// planned back to true, see resourceBotCustomizeDiff
+		detail += " The permission will be added on the next apply."
+	} else {
+		detail += " Set manage_lambda_permission = true or add an aws_lambda_permission for the alias."
+	}
+
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Missing lambda invoke permission",
+		Detail:   detail,
+	})
+
+	return diags
 }
 
 func resourceBotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -136,6 +702,8 @@ func resourceBotUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 	var diags diag.Diagnostics
 	var bot aws_client.LexBot
 
+	awsClient := meta.(*aws_client.AwsClient)
+
 	bot.Id = d.Get("id").(string)
 	bot.Name = d.Get("name").(string)
 	bot.Alias = d.Get("alias").(string)
@@ -146,11 +714,64 @@ func resourceBotUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 	bot.ArchivePath = d.Get("archive_path").(string)
 	bot.Version = d.Get("version").(string)
 	bot.SourceCodeHash = d.Get("source_code_hash").(string)
-	bot.Tags = convertTags(d.Get("tags").(map[string]interface{}))
+	bot.Tags = awsClient.MergeDefaultTags(convertTags(d.Get("tags").(map[string]interface{})))
+	bot.Locales = expandLocales(d.Get("locale").([]interface{}))
+	bot.ManageLambdaPermission = d.Get("manage_lambda_permission").(bool)
 
-	awsClient := meta.(*aws_client.AwsClient)
+	// to delete the custom vocabularies that are no longer configured
+	previousLocales, _ := d.GetChange("locale")
+	bot.PreviousLocales = expandLocales(previousLocales.([]interface{}))
+
+	qna, err := loadQna(d.Get("qna").([]interface{}), d.Get("qna_file").(string))
+	bot.Qna = qna
+
+	if err == nil {
+		err = prepareQna(d, &bot)
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to generate qna archive",
+			Detail:   fmt.Sprintf("Unable to generate qna archive, err: %s", err),
+		})
+		return diags
+	}
+
+	diags = append(diags, qnaTranslationWarnings(bot.Qna)...)
+
+	// resume a create that failed part way through, using the current config.
+	// the planned steps are unknown, so use those in state
+	completedSteps, _ := d.GetChange("completed_steps")
+
+	if !createCompleted(awsClient, completedSteps.([]interface{})) {
+
+		bot.CompletedSteps = expandCompletedSteps(completedSteps.([]interface{}))
+
+		err := awsClient.CreateBot(ctx, &bot)
+
+		d.Set("completed_steps", bot.CompletedSteps)
+		d.Set("version", bot.Version)
+		d.Set("alias_id", bot.AliasId)
+		if bot.AliasId != "" {
+			d.Set("alias_arn", awsClient.AliasArn(bot.Id, bot.AliasId))
+		}
+		d.Set("tags_all", awsClient.RemoveIgnoredTags(bot.Tags))
+		d.Set("alias_tags_match", true)
+		setLambdaPermissionPresent(d, bot.StepCompleted(aws_client.StepLambdaPermission) && bot.ManageLambdaPermission)
 
-	err := awsClient.UpdateBot(&bot, d)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to resume creating configured bot",
+				Detail:   fmt.Sprintf("Unable to resume creating configured bot, err: %s", err),
+			})
+		}
+
+		return diags
+	}
+
+	err = awsClient.UpdateBot(ctx, &bot, d)
 
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -166,10 +787,24 @@ func resourceBotUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 	d.Set("version", bot.Version)
 	// alias id may get updated with each update
 	d.Set("alias_id", bot.AliasId)
+	d.Set("alias_arn", awsClient.AliasArn(bot.Id, bot.AliasId))
+	d.Set("tags_all", awsClient.RemoveIgnoredTags(bot.Tags))
+	d.Set("alias_tags_match", true)
+	setLambdaPermissionPresent(d, bot.ManageLambdaPermission)
 
 	return diags
 }
 
+// after an update, a managed permission is present. one that is no longer
+// managed was removed, any other is found by the next refresh
+func setLambdaPermissionPresent(d *schema.ResourceData, managed bool) {
+	if managed {
+		d.Set("lambda_permission_present", true)
+	} else if d.HasChange("manage_lambda_permission") || d.HasChange("lambda_arn") || d.HasChange("alias") {
+		d.Set("lambda_permission_present", false)
+	}
+}
+
 func resourceBotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
@@ -180,7 +815,24 @@ func resourceBotDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	awsClient := meta.(*aws_client.AwsClient)
 
-	err := awsClient.DeleteBot(botId)
+	if d.Get("manage_lambda_permission").(bool) {
+		err := awsClient.RemoveLambdaPermission(ctx, &aws_client.LexBot{
+			Id:        botId,
+			AliasId:   d.Get("alias_id").(string),
+			LambdaArn: d.Get("lambda_arn").(string),
+		})
+
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to remove lambda permission",
+				Detail:   fmt.Sprintf("Unable to remove lambda permission, err: %s", err),
+			})
+			return diags
+		}
+	}
+
+	err := awsClient.DeleteBot(ctx, botId)
 
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scg/va/aws_client"
+)
+
+func resourceBotPromotion() *schema.Resource {
+	return &schema.Resource{
+		Description: "Promotes a bot version, e.g. from a dev account, into another bot",
+
+		CreateContext: resourceBotPromotionCreate,
+		ReadContext:   resourceBotPromotionRead,
+		DeleteContext: resourceBotPromotionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Bot version to promote",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bot_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "ID of the source bot",
+						},
+						"bot_version": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Version of the source bot to promote",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Region of the source bot. Defaults to the provider region",
+						},
+						"role_arn": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Role to assume to export the source bot, instead of the provider role",
+						},
+						"account_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Account id of the source role. Only needed with `skip_credentials_validation`",
+						},
+						"source_code_hash": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Only promote the source version if it was built from the archive with this hash",
+						},
+					},
+				},
+			},
+			"bot_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the target bot",
+			},
+			"alias": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Alias of the target bot pointed at the promoted version",
+				ValidateDiagFunc: AliasValidator,
+			},
+			"lambda_arn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Arn of router lambda of the target alias",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the target bot created from the source version",
+			},
+			"alias_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the target bot alias",
+			},
+			"source_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The promoted source version, as `<bot id>:<version>`",
+			},
+			"source_code_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Source code hash recorded with both the source and the target version",
+			},
+			"archive_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base64-encoded representation of the SHA-256 sum of the locales of the exported archive, without the identifiers lex assigns. The export of the target version must have the same hash",
+			},
+		},
+	}
+}
+
+func resourceBotPromotionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	source := d.Get("source").([]interface{})[0].(map[string]interface{})
+
+	promotion := aws_client.LexBotPromotion{
+		SourceBotId:            source["bot_id"].(string),
+		SourceVersion:          source["bot_version"].(string),
+		ExpectedSourceCodeHash: source["source_code_hash"].(string),
+		TargetBotId:            d.Get("bot_id").(string),
+		Alias:                  d.Get("alias").(string),
+		LambdaArn:              d.Get("lambda_arn").(string),
+	}
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	sourceClient, err := awsClient.WithSource(ctx, aws_client.PromotionSource{
+		Region:    source["region"].(string),
+		RoleArn:   source["role_arn"].(string),
+		AccountId: source["account_id"].(string),
+	})
+
+	if err == nil {
+		err = awsClient.PromoteBot(ctx, sourceClient, &promotion)
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to promote bot",
+			Detail:   fmt.Sprintf("Unable to promote bot, err: %s", err),
+		})
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", promotion.TargetBotId, promotion.Version))
+	d.Set("version", promotion.Version)
+	d.Set("alias_id", promotion.AliasId)
+	d.Set("source_version", fmt.Sprintf("%s:%s", promotion.SourceBotId, promotion.SourceVersion))
+	d.Set("source_code_hash", promotion.SourceCodeHash)
+	d.Set("archive_hash", promotion.ArchiveHash)
+
+	return diags
+}
+
+func resourceBotPromotionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	promotion := aws_client.LexBotPromotion{
+		TargetBotId: d.Get("bot_id").(string),
+		Version:     d.Get("version").(string),
+	}
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	err := awsClient.ReadPromotion(ctx, &promotion)
+
+	if aws_client.IsNotFoundError(err) {
+		// the promoted version was deleted, promote again
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read promoted bot version",
+			Detail:   fmt.Sprintf("Unable to read promoted bot version, err: %s", err),
+		})
+		return diags
+	}
+
+	d.Set("source_code_hash", promotion.SourceCodeHash)
+
+	// promote again when the alias was pointed at another version
+	bot, err := awsClient.GetBot(ctx, promotion.TargetBotId, d.Get("alias").(string))
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get target bot",
+			Detail:   fmt.Sprintf("Unable to get target bot, err: %s", err),
+		})
+		return diags
+	}
+
+	if bot.Version != promotion.Version || bot.AliasId != d.Get("alias_id").(string) {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+// the promoted version is kept, only the record of the promotion is removed
+func resourceBotPromotionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
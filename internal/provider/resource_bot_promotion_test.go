@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceBotPromotion(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBotPromotion,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"awslex_bot_promotion.foo", "source_version", "C5H22UIPWC:1"),
+					resource.TestCheckResourceAttrSet(
+						"awslex_bot_promotion.foo", "version"),
+				),
+			},
+		},
+	})
+}
+
+// promote the first version of the stable dev bot into the bot of the
+// bot resource example, within the same account
+const testAccResourceBotPromotion = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_resource" "target" {
+  name  = "TerraBot"
+  alias = "latest"
+}
+
+resource "awslex_bot_promotion" "foo" {
+  source {
+    bot_id      = "C5H22UIPWC"
+    bot_version = "1"
+  }
+
+  bot_id     = data.awslex_bot_resource.target.id
+  alias      = "promoted"
+  lambda_arn = data.awslex_bot_resource.target.lambda_arn
+}
+`
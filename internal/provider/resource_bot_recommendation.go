@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scg/va/aws_client"
+)
+
+func resourceBotRecommendation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Intents and slot types recommended by lex for a bot locale from conversation transcripts",
+
+		CreateContext: resourceBotRecommendationCreate,
+		ReadContext:   resourceBotRecommendationRead,
+		DeleteContext: resourceBotRecommendationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(aws_client.RecommendationWaitTimeoutSec * time.Second),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bot_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the bot. Recommendations are made for its draft version",
+			},
+			"locale_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the locale, e.g. en_US",
+			},
+			"transcript_source": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "S3 location of the conversation transcripts, in the lex transcript format",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_bucket_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Name of the bucket containing the transcripts",
+						},
+						"object_prefixes": {
+							Type:        schema.TypeList,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Prefixes of the transcript objects in the bucket",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"kms_key_arn": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Arn of the KMS key the transcripts are encrypted with",
+						},
+					},
+				},
+			},
+			"kms_key_arn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arn of the KMS key used to encrypt the recommendation results",
+			},
+			"output_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Directory the recommended locale is unpacked into, in the bot import/export layout",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the recommendation",
+			},
+			"intent_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of intents discovered in the transcripts",
+			},
+			"slot_type_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of slot types discovered in the transcripts",
+			},
+			"intents": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the recommended intents",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"slot_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the recommended slot types",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceBotRecommendationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	source := d.Get("transcript_source").([]interface{})[0].(map[string]interface{})
+
+	recommendation := aws_client.LexBotRecommendation{
+		BotId:               d.Get("bot_id").(string),
+		LocaleId:            d.Get("locale_id").(string),
+		S3BucketName:        source["s3_bucket_name"].(string),
+		TranscriptKmsKeyArn: source["kms_key_arn"].(string),
+		KmsKeyArn:           d.Get("kms_key_arn").(string),
+	}
+
+	for _, prefix := range source["object_prefixes"].([]interface{}) {
+		recommendation.ObjectPrefixes = append(recommendation.ObjectPrefixes, prefix.(string))
+	}
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	err := awsClient.StartBotRecommendation(ctx, &recommendation, d.Get("output_dir").(string))
+
+	// keep a recommendation that was started, so it can be stopped on destroy
+	if recommendation.Id != "" {
+		d.SetId(recommendation.Id)
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get bot recommendation",
+			Detail:   fmt.Sprintf("Unable to get bot recommendation, err: %s", err),
+		})
+		return diags
+	}
+
+	d.Set("status", recommendation.Status)
+	d.Set("intent_count", recommendation.IntentCount)
+	d.Set("slot_type_count", recommendation.SlotTypeCount)
+	d.Set("intents", recommendation.Intents)
+	d.Set("slot_types", recommendation.SlotTypes)
+
+	return diags
+}
+
+func resourceBotRecommendationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	status, err := awsClient.DescribeBotRecommendation(ctx, d.Get("bot_id").(string), d.Get("locale_id").(string), d.Id())
+
+	if aws_client.IsNotFoundError(err) {
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to describe bot recommendation",
+			Detail:   fmt.Sprintf("Unable to describe bot recommendation, err: %s", err),
+		})
+		return diags
+	}
+
+	d.Set("status", status)
+
+	return diags
+}
+
+// lex keeps completed recommendations, one still running is stopped
+func resourceBotRecommendationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if aws_client.RecommendationAvailable(d.Get("status").(string)) {
+		d.SetId("")
+		return diags
+	}
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	botId := d.Get("bot_id").(string)
+	localeId := d.Get("locale_id").(string)
+
+	status, err := awsClient.DescribeBotRecommendation(ctx, botId, localeId, d.Id())
+
+	if err == nil && aws_client.RecommendationRunning(status) {
+		err = awsClient.StopBotRecommendation(ctx, botId, localeId, d.Id())
+	}
+
+	if err != nil && !aws_client.IsNotFoundError(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to stop bot recommendation",
+			Detail:   fmt.Sprintf("Unable to stop bot recommendation, err: %s", err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
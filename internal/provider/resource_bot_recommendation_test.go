@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceBotRecommendation(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBotRecommendation,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"awslex_bot_recommendation.foo", "status", "Available"),
+					resource.TestCheckResourceAttrSet(
+						"awslex_bot_recommendation.foo", "intent_count"),
+				),
+			},
+		},
+	})
+}
+
+// transcripts of the stable dev bot, exported by its conversation logs
+const testAccResourceBotRecommendation = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+resource "awslex_bot_recommendation" "foo" {
+  bot_id    = "C5H22UIPWC"
+  locale_id = "en_US"
+
+  transcript_source {
+    s3_bucket_name  = "scg-lexbot-dev-wus2-transcripts"
+    object_prefixes = ["AWSLogs/111365482541/lex/"]
+  }
+}
+`
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scg/va/aws_client"
+)
+
+func resourceQnaIndex() *schema.Resource {
+	return &schema.Resource{
+		Description: "Question and answer documents in an OpenSearch or Elasticsearch index, for the fulfillment lambda to look up answers in",
+
+		CreateContext: resourceQnaIndexPut,
+		ReadContext:   resourceQnaIndexRead,
+		UpdateContext: resourceQnaIndexPut,
+		DeleteContext: resourceQnaIndexDelete,
+		CustomizeDiff: resourceQnaIndexCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"endpoint": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Url of the search endpoint, e.g. `http://localhost:9200` or the endpoint of an aws opensearch domain",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+			},
+			"index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the index. Documents written by the resource that are no longer in `qna_answers` are deleted",
+			},
+			"qna_answers": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Answers json, keyed by qna id and then locale, e.g. the `qna_answers` of an `awslex_bot_resource`",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User for basic authentication, e.g. with a local OpenSearch container",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password for basic authentication",
+			},
+			"aws_auth": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Sign requests with the provider credentials, for an aws opensearch domain",
+			},
+			"document_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the documents written by the resource that are in the index, `<qna id>:<locale>`",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"document_hashes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Hash of each document of `document_ids`, keyed by document id",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func expandQnaIndex(d *schema.ResourceData) aws_client.QnaIndex {
+	return aws_client.QnaIndex{
+		Endpoint: d.Get("endpoint").(string),
+		Index:    d.Get("index").(string),
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+		AwsAuth:  d.Get("aws_auth").(bool),
+	}
+}
+
+func expandDocumentIds(l []interface{}) []string {
+	ids := []string{}
+	for _, id := range l {
+		ids = append(ids, id.(string))
+	}
+	return ids
+}
+
+// write the documents and delete those written before that are not among
+// them. other documents of the index are left alone
+func resourceQnaIndexPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	index := expandQnaIndex(d)
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	pairs, err := aws_client.ParseQnaAnswers(d.Get("qna_answers").(string))
+
+	// the ids in state, empty on create
+	staleIds, _ := d.GetChange("document_ids")
+
+	if err == nil {
+		err = awsClient.PutQnaDocuments(ctx, index, pairs, expandDocumentIds(staleIds.([]interface{})))
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to write qna index",
+			Detail:   fmt.Sprintf("Unable to write qna index, err: %s", err),
+		})
+		return diags
+	}
+
+	d.SetId(strings.TrimRight(index.Endpoint, "/") + "/" + index.Index)
+
+	ids := []string{}
+	for _, qna := range pairs {
+		ids = append(ids, aws_client.QnaDocumentId(qna))
+	}
+	d.Set("document_ids", ids)
+
+	return resourceQnaIndexRead(ctx, d, meta)
+}
+
+// read back the documents written by the resource
+func resourceQnaIndexRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	hashes, err := awsClient.ReadQnaDocuments(ctx, expandQnaIndex(d), expandDocumentIds(d.Get("document_ids").([]interface{})))
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read qna index",
+			Detail:   fmt.Sprintf("Unable to read qna index, err: %s", err),
+		})
+		return diags
+	}
+
+	ids := []string{}
+	for id := range hashes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	d.Set("document_ids", ids)
+	d.Set("document_hashes", hashes)
+
+	return diags
+}
+
+// the index is left in place, only its documents are deleted
+func resourceQnaIndexDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	err := awsClient.DeleteQnaDocuments(ctx, expandQnaIndex(d), expandDocumentIds(d.Get("document_ids").([]interface{})))
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete qna index documents",
+			Detail:   fmt.Sprintf("Unable to delete qna index documents, err: %s", err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// plan the document ids and hashes, so documents deleted or edited outside of
+// terraform show up as a diff
+func resourceQnaIndexCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+
+	if !d.NewValueKnown("qna_answers") {
+		if err := d.SetNewComputed("document_ids"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("document_hashes")
+	}
+
+	pairs, err := aws_client.ParseQnaAnswers(d.Get("qna_answers").(string))
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	hashes := make(map[string]interface{})
+	for _, qna := range pairs {
+		ids = append(ids, aws_client.QnaDocumentId(qna))
+		hashes[aws_client.QnaDocumentId(qna)] = aws_client.QnaDocumentHash(qna)
+	}
+
+	if err := d.SetNew("document_ids", ids); err != nil {
+		return err
+	}
+	return d.SetNew("document_hashes", hashes)
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// runs against a local OpenSearch container, see docs/resources/qna_index.md
+func TestAccResourceQnaIndex(t *testing.T) {
+
+	endpoint := os.Getenv("AWSLEX_OPENSEARCH_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("AWSLEX_OPENSEARCH_ENDPOINT not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceQnaIndex, endpoint, `
+    password-reset = {
+      en_US = { answer = "Reset it in My Account", questions = ["I forgot my password"] }
+    }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"awslex_qna_index.foo", "document_ids.#", "3"),
+					resource.TestCheckResourceAttr(
+						"awslex_qna_index.foo", "document_ids.2", "password-reset:en_US"),
+					resource.TestCheckResourceAttr(
+						"awslex_qna_index.foo", "document_hashes.%", "3"),
+				),
+			},
+			{
+				// the stale document is deleted
+				Config: fmt.Sprintf(testAccResourceQnaIndex, endpoint, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"awslex_qna_index.foo", "document_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceQnaIndex = `
+# only the search endpoint is called
+provider "awslex" {
+  region                      = "us-west-2"
+  account_id                  = "000000000000"
+  skip_credentials_validation = true
+}
+
+resource "awslex_qna_index" "foo" {
+  endpoint = %q
+  index    = "awslex-acc-test-qna"
+
+  qna_answers = jsonencode({
+    gas-leak = {
+      en_US = { answer = "Call 911", questions = ["emergency gas leak"] }
+      es_US = { answer = "Llame al 911", questions = ["fuga de gas"] }
+    }%s
+  })
+}
+`
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scg/va/aws_client"
+)
+
+func resourceResourcePolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Resource-based policy attached to a v2 lex bot or bot alias",
+
+		CreateContext: resourceResourcePolicyCreate,
+		ReadContext:   resourceResourcePolicyRead,
+		UpdateContext: resourceResourcePolicyUpdate,
+		DeleteContext: resourceResourcePolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Arn of the bot or bot alias the policy is attached to",
+			},
+			"resource_arn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Arn of the bot or bot alias to attach the policy to",
+				ValidateDiagFunc: LexArnValidator,
+			},
+			"policy": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "IAM policy document, in json",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				DiffSuppressFunc: suppressEquivalentJson,
+			},
+			"revision_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current revision of the policy",
+			},
+		},
+	}
+}
+
+func resourceResourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	policy := aws_client.LexResourcePolicy{
+		ResourceArn: d.Get("resource_arn").(string),
+		Policy:      d.Get("policy").(string),
+	}
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	err := awsClient.CreateResourcePolicy(ctx, &policy)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create resource policy",
+			Detail:   fmt.Sprintf("Unable to create resource policy for %s, err: %s", policy.ResourceArn, err),
+		})
+		return diags
+	}
+
+	d.SetId(policy.ResourceArn)
+	d.Set("revision_id", policy.RevisionId)
+
+	return diags
+}
+
+func resourceResourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	policy, err := awsClient.GetResourcePolicy(ctx, d.Id())
+
+	if aws_client.IsNotFoundError(err) {
+		// the policy (or the bot it was attached to) was removed outside terraform
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get resource policy",
+			Detail:   fmt.Sprintf("Unable to get resource policy for %s, err: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	d.Set("resource_arn", policy.ResourceArn)
+	d.Set("policy", policy.Policy)
+	d.Set("revision_id", policy.RevisionId)
+
+	return diags
+}
+
+func resourceResourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	policy := aws_client.LexResourcePolicy{
+		ResourceArn: d.Id(),
+		Policy:      d.Get("policy").(string),
+		RevisionId:  d.Get("revision_id").(string),
+	}
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	err := awsClient.UpdateResourcePolicy(ctx, &policy)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to update resource policy",
+			Detail:   fmt.Sprintf("Unable to update resource policy for %s, err: %s", policy.ResourceArn, err),
+		})
+		return diags
+	}
+
+	d.Set("revision_id", policy.RevisionId)
+
+	return diags
+}
+
+func resourceResourcePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	policy := aws_client.LexResourcePolicy{
+		ResourceArn: d.Id(),
+		RevisionId:  d.Get("revision_id").(string),
+	}
+
+	awsClient := meta.(*aws_client.AwsClient)
+
+	err := awsClient.DeleteResourcePolicy(ctx, policy)
+
+	if err != nil && !aws_client.IsNotFoundError(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete resource policy",
+			Detail:   fmt.Sprintf("Unable to delete resource policy for %s, err: %s", policy.ResourceArn, err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func LexArnValidator(i interface{}, p cty.Path) diag.Diagnostics {
+	arn := i.(string)
+
+	match, err := regexp.Match(`^arn:aws[a-z-]*:lex:[a-z0-9-]+:\d{12}:(bot|bot-alias)/[A-Za-z0-9]+(/[A-Za-z0-9]+)?$`, []byte(arn))
+
+	if err != nil || !match {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid lex bot or bot alias arn",
+				Detail:   fmt.Sprintf("Invalid lex bot or bot alias arn: %s", arn),
+			},
+		}
+	}
+
+	return diag.Diagnostics{}
+}
+
+// policies are returned by aws with different whitespace and ordering
+func suppressEquivalentJson(k, old, new string, d *schema.ResourceData) bool {
+	var oldDoc, newDoc interface{}
+
+	if err := json.Unmarshal([]byte(old), &oldDoc); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newDoc); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldDoc, newDoc)
+}
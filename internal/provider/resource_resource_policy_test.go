@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceResourcePolicy(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceResourcePolicy,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"awslex_resource_policy.foo", "revision_id", regexp.MustCompile(".+")),
+				),
+			},
+		},
+	})
+}
+
+func TestLexArnValidator(t *testing.T) {
+
+	valid := []string{
+		"arn:aws:lex:us-west-2:111365482541:bot/C5H22UIPWC",
+		"arn:aws:lex:us-west-2:111365482541:bot-alias/C5H22UIPWC/TSTALIASID",
+		"arn:aws-us-gov:lex:us-gov-west-1:111365482541:bot/C5H22UIPWC",
+	}
+	invalid := []string{
+		"arn:aws:lambda:us-west-2:111365482541:function:foo",
+		"arn:aws:lex:us-west-2:111365482541:bot-alias/",
+		"C5H22UIPWC",
+	}
+
+	for _, arn := range valid {
+		if diags := LexArnValidator(arn, nil); diags.HasError() {
+			t.Errorf("expected %s to be valid", arn)
+		}
+	}
+	for _, arn := range invalid {
+		if diags := LexArnValidator(arn, nil); !diags.HasError() {
+			t.Errorf("expected %s to be invalid", arn)
+		}
+	}
+}
+
+// grant the contact center account access to the stable dev bot alias
+const testAccResourceResourcePolicy = `
+provider "awslex" {
+  region = "us-west-2"
+}
+
+data "awslex_bot_resource" "foo" {
+  id = "C5H22UIPWC"
+  alias = "latest"
+}
+
+resource "awslex_resource_policy" "foo" {
+  resource_arn = "arn:aws:lex:us-west-2:111365482541:bot-alias/${data.awslex_bot_resource.foo.id}/${data.awslex_bot_resource.foo.alias_id}"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Sid       = "ContactCenterRecognizeText"
+      Effect    = "Allow"
+      Principal = { AWS = "arn:aws:iam::123456789012:root" }
+      Action    = ["lex:RecognizeText"]
+      Resource  = "arn:aws:lex:us-west-2:111365482541:bot-alias/${data.awslex_bot_resource.foo.id}/${data.awslex_bot_resource.foo.alias_id}"
+    }]
+  })
+}
+`